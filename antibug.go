@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🛡️ ANTI-BUG SCANNER
+// ════════════════════════════════════════════════════════════════
+// ہر میسج کو اسکور دیا جاتا ہے (لمبائی، bidi/zero-width کثافت، combining
+// marks کے ڈھیر، بڑے vCard/لوکیشن)۔ اسکور threshold سے اوپر ہو تو ایکشن۔
+// DM: فی بوٹ Redis "antibug:<botID>"  |  گروپ: GroupSettings.AntiBug
+
+type AntiBugConfig struct {
+	Enabled   bool   `json:"enabled"` // DMs کے لیے
+	Action    string `json:"action"`  // revoke / block / quarantine
+	Threshold int    `json:"threshold"`
+}
+
+var (
+	antiBugCache = make(map[string]*AntiBugConfig)
+	antiBugMutex sync.RWMutex
+)
+
+const defaultBugThreshold = 60
+
+func getAntiBugConfig(botID string) *AntiBugConfig {
+	antiBugMutex.RLock()
+	cfg, exists := antiBugCache[botID]
+	antiBugMutex.RUnlock()
+	if exists {
+		return cfg
+	}
+
+	cfg = &AntiBugConfig{Action: "revoke", Threshold: defaultBugThreshold}
+	if rdb != nil {
+		if val, err := rdb.Get(ctx, "antibug:"+botID).Result(); err == nil {
+			json.Unmarshal([]byte(val), cfg)
+		}
+	}
+
+	antiBugMutex.Lock()
+	antiBugCache[botID] = cfg
+	antiBugMutex.Unlock()
+	return cfg
+}
+
+func saveAntiBugConfig(botID string, cfg *AntiBugConfig) {
+	antiBugMutex.Lock()
+	antiBugCache[botID] = cfg
+	antiBugMutex.Unlock()
+
+	if rdb == nil {
+		return
+	}
+	jsonData, err := json.Marshal(cfg)
+	if err != nil {
+		return
+	}
+	if err := rdb.Set(ctx, "antibug:"+botID, jsonData, 0).Err(); err != nil {
+		fmt.Printf("⚠️ [REDIS ERROR] Failed to save antibug config: %v\n", err)
+	}
+}
+
+// ==================== اسکورنگ ====================
+
+// bidi overrides، zero-width اور دوسرے نظر نہ آنے والے کیریکٹرز
+func isInvisibleRune(r rune) bool {
+	switch {
+	case r >= 0x200B && r <= 0x200F, // ZWSP, ZWNJ, ZWJ, LRM, RLM
+		r >= 0x202A && r <= 0x202E, // LRE, RLE, PDF, LRO, RLO
+		r >= 0x2060 && r <= 0x2064, // Word Joiner وغیرہ
+		r >= 0x2066 && r <= 0x2069, // LRI, RLI, FSI, PDI
+		r == 0xFEFF, r == 0x034F:
+		return true
+	}
+	return false
+}
+
+func scoreBugText(text string) (int, []string) {
+	if text == "" {
+		return 0, nil
+	}
+	score := 0
+	var reasons []string
+
+	runes := []rune(text)
+	n := len(runes)
+
+	// A. لمبائی
+	if n > 20000 {
+		score += 60
+		reasons = append(reasons, fmt.Sprintf("Huge text (%d chars)", n))
+	} else if n > 4000 {
+		score += 25
+		reasons = append(reasons, fmt.Sprintf("Long text (%d chars)", n))
+	}
+
+	// B. invisible کثافت اور C. combining marks کا ڈھیر
+	invisible, marks, stack, maxStack := 0, 0, 0, 0
+	for _, r := range runes {
+		if isInvisibleRune(r) {
+			invisible++
+		}
+		if unicode.Is(unicode.Mn, r) {
+			marks++
+			stack++
+			if stack > maxStack {
+				maxStack = stack
+			}
+		} else {
+			stack = 0
+		}
+	}
+
+	if invisible > 50 {
+		score += 40
+		reasons = append(reasons, fmt.Sprintf("%d bidi/zero-width chars", invisible))
+	}
+	if n >= 20 && float64(invisible)/float64(n) > 0.3 {
+		score += 30
+		reasons = append(reasons, fmt.Sprintf("Invisible density %d%%", invisible*100/n))
+	}
+	if maxStack > 15 {
+		score += 60 // اکیلا یہی کریش کے لیے کافی ہے
+		reasons = append(reasons, fmt.Sprintf("Combining stack of %d marks", maxStack))
+	} else if marks > 500 {
+		score += 20
+		reasons = append(reasons, fmt.Sprintf("%d combining marks", marks))
+	}
+
+	return score, reasons
+}
+
+// پورے میسج کا اسکور (ٹیکسٹ، کیپشن، vCard، لوکیشن)
+func scoreBugPayload(m *waProto.Message) (int, []string) {
+	if m == nil {
+		return 0, nil
+	}
+	score, reasons := scoreBugText(getText(m))
+
+	add := func(s int, r []string) {
+		score += s
+		reasons = append(reasons, r...)
+	}
+
+	if doc := m.GetDocumentMessage(); doc != nil {
+		add(scoreBugText(doc.GetFileName()))
+	}
+
+	if c := m.GetContactMessage(); c != nil {
+		if len(c.GetVcard()) > 10000 {
+			add(60, []string{fmt.Sprintf("Oversized vCard (%d bytes)", len(c.GetVcard()))})
+		}
+		add(scoreBugText(c.GetDisplayName()))
+	}
+
+	if arr := m.GetContactsArrayMessage(); arr != nil {
+		total := 0
+		for _, c := range arr.GetContacts() {
+			total += len(c.GetVcard())
+		}
+		if len(arr.GetContacts()) > 50 {
+			add(40, []string{fmt.Sprintf("%d contacts in one message", len(arr.GetContacts()))})
+		}
+		if total > 50000 {
+			add(60, []string{fmt.Sprintf("Oversized vCards (%d bytes)", total)})
+		}
+	}
+
+	if loc := m.GetLocationMessage(); loc != nil {
+		text := loc.GetName() + loc.GetAddress()
+		if len(text) > 1000 {
+			add(60, []string{fmt.Sprintf("Oversized location (%d bytes)", len(text))})
+		}
+		add(scoreBugText(text))
+	}
+
+	return score, reasons
+}
+
+// ==================== ایکشن ====================
+
+// true واپس آئے تو میسج مزید پروسیس نہ کریں
+func scanIncomingForBugs(client *whatsmeow.Client, v *events.Message) bool {
+	if v.Info.IsFromMe {
+		return false
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getAntiBugConfig(botID)
+
+	action := cfg.Action
+	if v.Info.IsGroup {
+		s := getGroupSettings(botID, v.Info.Chat.String())
+		if !s.AntiBug {
+			return false
+		}
+		action = s.AntiBugAction
+		if action == "" {
+			action = "quarantine"
+		}
+	} else if !cfg.Enabled || isOwner(client, v.Info.Sender) || isSudo(client, v.Info.Sender) {
+		return false
+	}
+
+	threshold := cfg.Threshold
+	if threshold <= 0 {
+		threshold = defaultBugThreshold
+	}
+
+	score, reasons := scoreBugPayload(v.Message)
+	if score < threshold {
+		return false
+	}
+
+	fmt.Printf("🛡️ [ANTI-BUG] Score %d from %s in %s | %s\n", score, v.Info.Sender.User, v.Info.Chat.User, strings.Join(reasons, ", "))
+	applyBugAction(client, v, action, score, reasons)
+	return true
+}
+
+func applyBugAction(client *whatsmeow.Client, v *events.Message, action string, score int, reasons []string) {
+	chat := v.Info.Chat
+	sender := v.Info.Sender
+	reason := fmt.Sprintf("Bug payload (score %d)", score)
+
+	// 1. ہمیشہ پہلے ہٹائیں
+	if v.Info.IsGroup {
+		client.SendMessage(context.Background(), chat, client.BuildRevoke(chat, sender, v.Info.ID))
+	} else {
+		client.RevokeMessage(context.Background(), chat, v.Info.ID)
+	}
+
+	if action == "kick" || action == "block" {
+		if v.Info.IsGroup {
+			_, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{sender}, whatsmeow.ParticipantChangeRemove)
+			if err != nil {
+				fmt.Printf("⚠️ [ANTI-BUG] Kick failed: %v\n", err)
+			}
+		}
+	}
+	if action == "block" {
+		if _, err := client.UpdateBlocklist(context.Background(), sender.ToNonAD(), events.BlocklistChangeActionBlock); err != nil {
+			fmt.Printf("⚠️ [ANTI-BUG] Block failed: %v\n", err)
+		}
+	}
+
+	if v.Info.IsGroup {
+		logAction := "delete"
+		if action == "kick" || action == "block" {
+			logAction = "kick"
+		}
+		logModAction(client, chat, logAction, reason, types.EmptyJID, sender, "")
+	}
+
+	if action == "revoke" {
+		return
+	}
+
+	// 2. Quarantine نوٹس
+	out := "╔════════════════╗\n"
+	out += "║ 🛡️ BUG BLOCKED\n"
+	out += "╠════════════════╣\n"
+	out += fmt.Sprintf("║ 👤 From: @%s\n", sender.User)
+	out += fmt.Sprintf("║ 📊 Score: %d\n", score)
+	for _, r := range reasons {
+		out += "║ • " + r + "\n"
+	}
+	out += "║ ⚡ Action: " + strings.ToUpper(action) + "\n"
+	out += "╠════════════════╣\n"
+	out += "║ ⚠️ If the chat lags, clear it\n"
+	out += "╚════════════════╝"
+
+	if v.Info.IsGroup {
+		sendMentionText(client, chat, out, []string{sender.String()})
+	} else {
+		notifyOwner(client, out)
+	}
+}
+
+// ==================== کمانڈ ====================
+
+func handleAntiBug(client *whatsmeow.Client, v *events.Message, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getAntiBugConfig(botID)
+
+	arg := ""
+	if len(args) > 0 {
+		arg = strings.ToLower(args[0])
+	}
+	val := ""
+	if len(args) > 1 {
+		val = strings.ToLower(args[1])
+	}
+
+	// 👥 گروپ: ایڈمن اس گروپ کے لیے
+	if v.Info.IsGroup {
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		s := getGroupSettings(botID, v.Info.Chat.String())
+
+		switch arg {
+		case "setup":
+			startWizard(client, v, "antibug", botID, v.Info.Chat.String())
+			return
+		case "on":
+			s.AntiBug = true
+			replyMessage(client, v, "✅ *Anti-Bug:* ON for this group")
+		case "off":
+			s.AntiBug = false
+			replyMessage(client, v, "❌ *Anti-Bug:* OFF for this group")
+		case "action":
+			if val != "revoke" && val != "quarantine" && val != "kick" && val != "block" {
+				replyMessage(client, v, "⚠️ Usage: .antibug action revoke | quarantine | kick | block")
+				return
+			}
+			s.AntiBugAction = val
+			replyMessage(client, v, "✅ Anti-Bug action set to "+strings.ToUpper(val))
+		default:
+			status := "🔴 DISABLED"
+			if s.AntiBug {
+				status = "🟢 ENABLED"
+			}
+			action := s.AntiBugAction
+			if action == "" {
+				action = "quarantine"
+			}
+			msg := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ANTI-BUG (GROUP)
+╠════════════════╣
+║ Status: %s
+║ Action: %s
+║ Threshold: %d
+╠════════════════╣
+║ .antibug on/off
+║ .antibug setup
+║ .antibug action revoke/
+║   quarantine/kick/block
+╚════════════════╝`, status, strings.ToUpper(action), cfg.Threshold)
+			replyMessage(client, v, msg)
+			return
+		}
+		saveGroupSettings(botID, s)
+		return
+	}
+
+	// 👤 DM: اونر، بوٹ کی پرائیویٹ چیٹس کے لیے
+	if !requireRole(client, v, RoleSudo) {
+		return
+	}
+
+	switch arg {
+	case "setup":
+		startWizard(client, v, "antibug_dm", botID, v.Info.Chat.String())
+		return
+	case "on":
+		cfg.Enabled = true
+		replyMessage(client, v, "✅ *Anti-Bug:* ON for private chats")
+	case "off":
+		cfg.Enabled = false
+		replyMessage(client, v, "❌ *Anti-Bug:* OFF for private chats")
+	case "action":
+		if val != "revoke" && val != "quarantine" && val != "block" {
+			replyMessage(client, v, "⚠️ Usage: .antibug action revoke | quarantine | block")
+			return
+		}
+		cfg.Action = val
+		replyMessage(client, v, "✅ Anti-Bug DM action set to "+strings.ToUpper(val))
+	case "threshold":
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 10 || n > 500 {
+			replyMessage(client, v, "⚠️ Usage: .antibug threshold <10-500>")
+			return
+		}
+		cfg.Threshold = n
+		replyMessage(client, v, fmt.Sprintf("✅ Anti-Bug threshold set to %d", n))
+	default:
+		status := "🔴 DISABLED"
+		if cfg.Enabled {
+			status = "🟢 ENABLED"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ANTI-BUG (DM)
+╠════════════════╣
+║ Status: %s
+║ Action: %s
+║ Threshold: %d
+╠════════════════╣
+║ .antibug on/off
+║ .antibug setup
+║ .antibug action revoke/
+║   quarantine/block
+║ .antibug threshold 60
+╚════════════════╝`, status, strings.ToUpper(cfg.Action), cfg.Threshold)
+		replyMessage(client, v, msg)
+		return
+	}
+	saveAntiBugConfig(botID, cfg)
+}
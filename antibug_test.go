@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func zalgo(base string, marks int) string {
+	var b strings.Builder
+	for _, r := range base {
+		b.WriteRune(r)
+		for i := 0; i < marks; i++ {
+			b.WriteRune(rune(0x0300 + i%0x6F))
+		}
+	}
+	return b.String()
+}
+
+func TestScoreBugText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		bug  bool
+	}{
+		{"bidi override flood", strings.Repeat("\u202e\u202d", 40) + "hello", true},
+		{"zero-width flood", "hi" + strings.Repeat("\u200b\u200c\u200d\u2060", 30), true},
+		{"isolate flood", strings.Repeat("\u2066\u2069", 30), true},
+		{"combining stack", zalgo("crash", 25), true},
+		{"huge text", strings.Repeat("a", 25000), true},
+		{"english", "Hello everyone, meeting is at 5pm today 👍", false},
+		{"urdu", "السلام علیکم! آج شام پانچ بجے میٹنگ ہے، سب وقت پر آ جائیں۔", false},
+		{"urdu with harakat", "بِسْمِ ٱللَّٰهِ ٱلرَّحْمَٰنِ ٱلرَّحِيمِ", false},
+		{"emoji", strings.Repeat("😂🔥❤️👍🏽👨\u200d👩\u200d👧\u200d👦", 20), false},
+		{"flag and zwj emoji", "🇵🇰 🏳️\u200d🌈 👩🏽\u200d💻 🧑\u200d🤝\u200d🧑", false},
+		{"long normal text", strings.Repeat("یہ ایک عام پیغام ہے۔ ", 150), false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := scoreBugText(tt.text)
+			if got := score >= defaultBugThreshold; got != tt.bug {
+				t.Errorf("score = %d %v, want bug=%v (threshold %d)", score, reasons, tt.bug, defaultBugThreshold)
+			}
+		})
+	}
+}
+
+func TestScoreBugPayload(t *testing.T) {
+	bigVcard := "BEGIN:VCARD\nVERSION:3.0\nFN:" + strings.Repeat("X", 12000) + "\nEND:VCARD"
+	smallVcard := "BEGIN:VCARD\nVERSION:3.0\nFN:Ali Khan\nTEL;type=CELL:+923001234567\nEND:VCARD"
+
+	var manyContacts []*waProto.ContactMessage
+	for i := 0; i < 60; i++ {
+		manyContacts = append(manyContacts, &waProto.ContactMessage{
+			DisplayName: proto.String("c"),
+			Vcard:       proto.String(smallVcard),
+		})
+	}
+
+	tests := []struct {
+		name string
+		msg  *waProto.Message
+		bug  bool
+	}{
+		{
+			"oversized vcard",
+			&waProto.Message{ContactMessage: &waProto.ContactMessage{
+				DisplayName: proto.String("Ali"),
+				Vcard:       proto.String(bigVcard),
+			}},
+			true,
+		},
+		{
+			"contact array flood",
+			&waProto.Message{ContactsArrayMessage: &waProto.ContactsArrayMessage{
+				DisplayName: proto.String("contacts"),
+				Contacts:    append(manyContacts, &waProto.ContactMessage{Vcard: proto.String(bigVcard + bigVcard + bigVcard + bigVcard + bigVcard)}),
+			}},
+			true,
+		},
+		{
+			"oversized location",
+			&waProto.Message{LocationMessage: &waProto.LocationMessage{
+				DegreesLatitude:  proto.Float64(24.86),
+				DegreesLongitude: proto.Float64(67.01),
+				Name:             proto.String(strings.Repeat("Karachi ", 100)),
+				Address:          proto.String(strings.Repeat("Saddar ", 100)),
+			}},
+			true,
+		},
+		{
+			"bidi caption",
+			&waProto.Message{ImageMessage: &waProto.ImageMessage{
+				Caption: proto.String(strings.Repeat("\u202e\u200f", 40)),
+			}},
+			true,
+		},
+		{
+			"combining stack in file name",
+			&waProto.Message{DocumentMessage: &waProto.DocumentMessage{
+				FileName: proto.String(zalgo("report", 20) + ".pdf"),
+			}},
+			true,
+		},
+		{
+			"normal contact",
+			&waProto.Message{ContactMessage: &waProto.ContactMessage{
+				DisplayName: proto.String("علی خان"),
+				Vcard:       proto.String(smallVcard),
+			}},
+			false,
+		},
+		{
+			"normal location",
+			&waProto.Message{LocationMessage: &waProto.LocationMessage{
+				DegreesLatitude:  proto.Float64(31.52),
+				DegreesLongitude: proto.Float64(74.35),
+				Name:             proto.String("بادشاہی مسجد"),
+				Address:          proto.String("Walled City, Lahore"),
+			}},
+			false,
+		},
+		{
+			"urdu text",
+			&waProto.Message{Conversation: proto.String("کل چھٹی ہے؟ 🤔 بتا دیں پلیز 🙏")},
+			false,
+		},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := scoreBugPayload(tt.msg)
+			if got := score >= defaultBugThreshold; got != tt.bug {
+				t.Errorf("score = %d %v, want bug=%v (threshold %d)", score, reasons, tt.bug, defaultBugThreshold)
+			}
+		})
+	}
+}
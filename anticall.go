@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📵 ANTI-CALL SYSTEM (Per Bot)
+// ════════════════════════════════════════════════════════════════
+// Config:   Redis "anticall:<botID>" (JSON)
+// Counters: Redis Hash "anticall_stats:<botID>" → per caller + totals
+
+type AntiCallConfig struct {
+	Enabled    bool     `json:"enabled"`
+	Reject     bool     `json:"reject"`
+	Reply      string   `json:"reply"`
+	BlockAfter int      `json:"block_after"` // 0 = کبھی بلاک نہ کریں
+	Whitelist  []string `json:"whitelist"`
+}
+
+var (
+	antiCallCache = make(map[string]*AntiCallConfig)
+	antiCallMutex sync.RWMutex
+)
+
+const defaultCallReply = "📵 *Auto Reply:* This number does not accept calls. Please send a message instead."
+
+func getAntiCallConfig(botID string) *AntiCallConfig {
+	antiCallMutex.RLock()
+	cfg, exists := antiCallCache[botID]
+	antiCallMutex.RUnlock()
+	if exists {
+		return cfg
+	}
+
+	cfg = &AntiCallConfig{Reject: true, Reply: defaultCallReply}
+	if rdb != nil {
+		if val, err := rdb.Get(ctx, "anticall:"+botID).Result(); err == nil {
+			json.Unmarshal([]byte(val), cfg)
+		}
+	}
+
+	antiCallMutex.Lock()
+	antiCallCache[botID] = cfg
+	antiCallMutex.Unlock()
+	return cfg
+}
+
+func saveAntiCallConfig(botID string, cfg *AntiCallConfig) {
+	antiCallMutex.Lock()
+	antiCallCache[botID] = cfg
+	antiCallMutex.Unlock()
+
+	if rdb == nil {
+		return
+	}
+	jsonData, err := json.Marshal(cfg)
+	if err != nil {
+		return
+	}
+	if err := rdb.Set(ctx, "anticall:"+botID, jsonData, 0).Err(); err != nil {
+		fmt.Printf("⚠️ [REDIS ERROR] Failed to save anticall config: %v\n", err)
+	}
+}
+
+// 📊 کل ریجیکٹ اور بلاک کالز (.data کے لیے)
+func getCallStats(botID string) (int64, int64) {
+	if rdb == nil {
+		return 0, 0
+	}
+	vals, err := rdb.HMGet(ctx, "anticall_stats:"+botID, "total_rejected", "total_blocked").Result()
+	if err != nil {
+		return 0, 0
+	}
+	toInt := func(x interface{}) int64 {
+		s, _ := x.(string)
+		n, _ := strconv.ParseInt(s, 10, 64)
+		return n
+	}
+	return toInt(vals[0]), toInt(vals[1])
+}
+
+func isCallWhitelisted(client *whatsmeow.Client, cfg *AntiCallConfig, caller types.JID) bool {
+	if isOwner(client, caller) || isSudo(client, caller) {
+		return true
+	}
+	for _, alias := range getUserAliases(client, caller) {
+		for _, w := range cfg.Whitelist {
+			if alias == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 📞 CallOffer ایونٹ ہینڈلر
+func handleIncomingCall(client *whatsmeow.Client, call types.BasicCallMeta) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("⚠️ [ANTI-CALL] Panic: %v\n", r)
+		}
+	}()
+
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getAntiCallConfig(botID)
+	if !cfg.Enabled {
+		return
+	}
+
+	caller := call.From.ToNonAD()
+	if isCallWhitelisted(client, cfg, caller) {
+		return
+	}
+
+	fmt.Printf("📵 [ANTI-CALL] Bot %s | Call from %s\n", botID, caller.User)
+
+	if cfg.Reject {
+		if err := client.RejectCall(context.Background(), call.From, call.CallID); err != nil {
+			fmt.Printf("⚠️ [ANTI-CALL] Reject failed: %v\n", err)
+		}
+	}
+
+	var count int64
+	statsKey := "anticall_stats:" + botID
+	if rdb != nil {
+		count, _ = rdb.HIncrBy(ctx, statsKey, getCleanID(caller.User), 1).Result()
+		if cfg.Reject {
+			rdb.HIncrBy(ctx, statsKey, "total_rejected", 1)
+		}
+	}
+
+	if cfg.BlockAfter > 0 && count >= int64(cfg.BlockAfter) {
+		client.SendMessage(context.Background(), caller, &waProto.Message{
+			Conversation: proto.String(fmt.Sprintf("🚫 You have been blocked for calling %d times.", count)),
+		})
+		if _, err := client.UpdateBlocklist(context.Background(), caller, events.BlocklistChangeActionBlock); err != nil {
+			fmt.Printf("⚠️ [ANTI-CALL] Block failed: %v\n", err)
+			return
+		}
+		if rdb != nil {
+			rdb.HIncrBy(ctx, statsKey, "total_blocked", 1)
+			rdb.HDel(ctx, statsKey, getCleanID(caller.User))
+		}
+		fmt.Printf("🚫 [ANTI-CALL] %s blocked after %d calls\n", caller.User, count)
+		return
+	}
+
+	if cfg.Reply != "" {
+		client.SendMessage(context.Background(), caller, &waProto.Message{
+			Conversation: proto.String(cfg.Reply),
+		})
+	}
+}
+
+// ==================== کمانڈ ====================
+
+func handleAntiCall(client *whatsmeow.Client, v *events.Message, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getAntiCallConfig(botID)
+
+	if len(args) == 0 {
+		onOff := func(b bool) string {
+			if b {
+				return "🟢 ON"
+			}
+			return "🔴 OFF"
+		}
+		block := "Never"
+		if cfg.BlockAfter > 0 {
+			block = fmt.Sprintf("After %d calls", cfg.BlockAfter)
+		}
+		reply := cfg.Reply
+		if reply == "" {
+			reply = "OFF"
+		}
+		rejected, blocked := getCallStats(botID)
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 📵 ANTI-CALL STATUS
+╠════════════════╣
+║ Status: %s
+║ Auto Reject: %s
+║ Block: %s
+║ Whitelist: %d
+║ Reply: %s
+╠════════════════╣
+║ 📞 Rejected: %d
+║ 🚫 Blocked: %d
+╠════════════════╣
+║ .anticall on/off
+║ .anticall setup
+║ .anticall reject on/off
+║ .anticall msg <text|off>
+║ .anticall block <n|0>
+║ .anticall allow <num>
+║ .anticall disallow <num>
+║ .anticall reset
+╚════════════════╝`, onOff(cfg.Enabled), onOff(cfg.Reject), block, len(cfg.Whitelist), reply, rejected, blocked)
+		replyMessage(client, v, msg)
+		return
+	}
+
+	opt := strings.ToLower(args[0])
+	rest := args[1:]
+	val := ""
+	if len(rest) > 0 {
+		val = strings.ToLower(rest[0])
+	}
+
+	switch opt {
+	case "setup":
+		startWizard(client, v, "anticall", botID, v.Info.Chat.String())
+		return
+	case "on":
+		cfg.Enabled = true
+	case "off":
+		cfg.Enabled = false
+	case "reject":
+		if val != "on" && val != "off" {
+			replyMessage(client, v, "⚠️ Usage: .anticall reject on | off")
+			return
+		}
+		cfg.Reject = val == "on"
+	case "msg", "reply":
+		if len(rest) == 0 {
+			replyMessage(client, v, "⚠️ Usage: .anticall msg <text> | off")
+			return
+		}
+		if val == "off" {
+			cfg.Reply = ""
+		} else {
+			cfg.Reply = strings.Join(rest, " ")
+		}
+	case "block":
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			replyMessage(client, v, "⚠️ Usage: .anticall block <calls> (0 = never)")
+			return
+		}
+		cfg.BlockAfter = n
+	case "allow", "disallow":
+		if len(rest) == 0 {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .anticall %s <number>", opt))
+			return
+		}
+		num := getCleanID(strings.TrimPrefix(strings.ReplaceAll(rest[0], "+", ""), "@"))
+		var list []string
+		for _, w := range cfg.Whitelist {
+			if w != num {
+				list = append(list, w)
+			}
+		}
+		if opt == "allow" {
+			list = append(list, num)
+		}
+		cfg.Whitelist = list
+	case "reset":
+		if rdb != nil {
+			rdb.Del(ctx, "anticall_stats:"+botID)
+		}
+		replyMessage(client, v, "✅ Call counters reset.")
+		return
+	default:
+		replyMessage(client, v, "⚠️ Invalid option. Use .anticall to see help.")
+		return
+	}
+
+	saveAntiCallConfig(botID, cfg)
+	replyMessage(client, v, "✅ Anti-Call settings updated.")
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ════════════════════════════════════════════════════════════════
+// ♻️ ANTI-DELETE
+// ════════════════════════════════════════════════════════════════
+// Recent messages of opted-in groups are kept in a bounded FIFO cache.
+// When the original sender revokes one, the bot re-posts it (or forwards
+// it to the owner's DM). Deletions made by admins are left alone.
+
+type cachedMessage struct {
+	ChatID  string
+	Sender  types.JID
+	Message *waProto.Message
+	At      time.Time
+}
+
+var (
+	antiDeleteCache = make(map[string]*cachedMessage) // chatID:msgID -> message
+	antiDeleteOrder []string
+	antiDeleteMutex sync.Mutex
+)
+
+const (
+	antiDeleteMaxEntries = 3000
+	antiDeleteTTL        = 30 * time.Minute
+)
+
+func cacheForAntiDelete(v *events.Message) {
+	key := v.Info.Chat.String() + ":" + v.Info.ID
+	now := time.Now()
+
+	antiDeleteMutex.Lock()
+	defer antiDeleteMutex.Unlock()
+
+	if _, exists := antiDeleteCache[key]; !exists {
+		antiDeleteOrder = append(antiDeleteOrder, key)
+	}
+	antiDeleteCache[key] = &cachedMessage{
+		ChatID:  v.Info.Chat.String(),
+		Sender:  v.Info.Sender,
+		Message: v.Message,
+		At:      now,
+	}
+
+	// 🧹 پرانے یا اضافی میسجز نکالیں (FIFO، اس لیے شروع سے)
+	for len(antiDeleteOrder) > 0 {
+		oldest := antiDeleteOrder[0]
+		m, ok := antiDeleteCache[oldest]
+		if ok && len(antiDeleteOrder) <= antiDeleteMaxEntries && now.Sub(m.At) < antiDeleteTTL {
+			break
+		}
+		delete(antiDeleteCache, oldest)
+		antiDeleteOrder = antiDeleteOrder[1:]
+	}
+}
+
+func popAntiDeleteCache(chatID, msgID string) *cachedMessage {
+	key := chatID + ":" + msgID
+	antiDeleteMutex.Lock()
+	defer antiDeleteMutex.Unlock()
+	m, ok := antiDeleteCache[key]
+	if !ok || time.Since(m.At) > antiDeleteTTL {
+		return nil
+	}
+	delete(antiDeleteCache, key) // آرڈر لسٹ سے بعد میں خود نکل جائے گا
+	return m
+}
+
+// true واپس آئے تو یہ ریووک میسج تھا، مزید پروسیسنگ کی ضرورت نہیں
+func handleAntiDelete(client *whatsmeow.Client, v *events.Message) bool {
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	if !s.AntiDelete {
+		return false
+	}
+
+	protoMsg := v.Message.GetProtocolMessage()
+	if protoMsg == nil || protoMsg.GetType() != waProto.ProtocolMessage_REVOKE {
+		if protoMsg == nil && !v.Info.IsFromMe {
+			cacheForAntiDelete(v)
+		}
+		return false
+	}
+
+	orig := popAntiDeleteCache(v.Info.Chat.String(), protoMsg.GetKey().GetID())
+	if orig == nil {
+		return true
+	}
+	// ایڈمن یا بوٹ نے ڈیلیٹ کیا ہو تو ری پوسٹ نہ کریں
+	if getCleanID(orig.Sender.User) != getCleanID(v.Info.Sender.User) {
+		return true
+	}
+
+	repostDeleted(client, s, v.Info.Chat, orig)
+	return true
+}
+
+func repostDeleted(client *whatsmeow.Client, s *GroupSettings, chat types.JID, orig *cachedMessage) {
+	dest := chat
+	toOwner := s.AntiDeleteMode == "dm"
+	if toOwner {
+		if client.Store.ID == nil {
+			return
+		}
+		dest = client.Store.ID.ToNonAD()
+	}
+
+	text := getText(orig.Message)
+	kind := deletedMessageKind(orig.Message)
+
+	out := "╔════════════════╗\n"
+	out += "║ ♻️ ANTI-DELETE\n"
+	out += "╠════════════════╣\n"
+	out += fmt.Sprintf("║ 👤 User: @%s\n", orig.Sender.User)
+	if toOwner {
+		out += "║ 👥 Group: " + chat.String() + "\n"
+	}
+	out += fmt.Sprintf("║ 🕒 Sent: %s\n", orig.At.Format("15:04:05"))
+	out += "║ 📎 Type: " + kind + "\n"
+	out += "╠════════════════╣\n"
+	if text != "" {
+		out += "║ 💬 " + strings.ReplaceAll(text, "\n", "\n║ ") + "\n"
+	}
+	out += "╚════════════════╝"
+
+	sendMentionText(client, dest, out, []string{orig.Sender.String()})
+
+	// 🖼️ میڈیا ہو تو وہی میڈیا ریفرنس دوبارہ بھیجیں
+	if kind != "Text" {
+		media := proto.Clone(orig.Message).(*waProto.Message)
+		if _, err := client.SendMessage(context.Background(), dest, media); err != nil {
+			fmt.Printf("⚠️ [ANTI-DELETE] Media re-post failed: %v\n", err)
+		}
+	}
+}
+
+func deletedMessageKind(m *waProto.Message) string {
+	switch {
+	case m.ImageMessage != nil:
+		return "Image"
+	case m.VideoMessage != nil:
+		return "Video"
+	case m.AudioMessage != nil:
+		return "Audio"
+	case m.StickerMessage != nil:
+		return "Sticker"
+	case m.DocumentMessage != nil:
+		return "Document"
+	}
+	return "Text"
+}
+
+// ==================== کمانڈ ====================
+
+func handleAntiDeleteCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	arg := ""
+	if len(args) > 0 {
+		arg = strings.ToLower(args[0])
+	}
+
+	switch arg {
+	case "setup":
+		startWizard(client, v, "antidelete", botID, v.Info.Chat.String())
+		return
+	case "on":
+		s.AntiDelete = true
+		replyMessage(client, v, "✅ *Anti-Delete:* ON")
+	case "off":
+		s.AntiDelete = false
+		replyMessage(client, v, "❌ *Anti-Delete:* OFF")
+	case "dm":
+		if !requireRole(client, v, RoleSudo) {
+			return
+		}
+		s.AntiDeleteMode = "dm"
+		replyMessage(client, v, "📩 Deleted messages will be forwarded to the owner's DM.")
+	case "group":
+		s.AntiDeleteMode = ""
+		replyMessage(client, v, "👥 Deleted messages will be re-posted in this group.")
+	default:
+		status := "🔴 DISABLED"
+		if s.AntiDelete {
+			status = "🟢 ENABLED"
+		}
+		dest := "Group"
+		if s.AntiDeleteMode == "dm" {
+			dest = "Owner DM"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ♻️ ANTI-DELETE
+╠════════════════╣
+║ Status: %s
+║ Re-post to: %s
+╠════════════════╣
+║ .antidelete on/off
+║ .antidelete setup
+║ .antidelete group/dm
+╚════════════════╝`, status, dest)
+		replyMessage(client, v, msg)
+		return
+	}
+	saveGroupSettings(botID, s)
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🚫 BAN LIST SYSTEM
+// ════════════════════════════════════════════════════════════════
+// Group bans: Redis Hash "bans:<chatID>"  (تمام بوٹس کے لیے ایک ہی لسٹ)
+// Global bans: Redis Hash "global_bans"  (اونر لیول، ہر گروپ میں لاگو)
+
+type BanEntry struct {
+	User     string    `json:"user"`
+	Reason   string    `json:"reason"`
+	By       string    `json:"by"`
+	Global   bool      `json:"global"`
+	BannedAt time.Time `json:"banned_at"`
+}
+
+func banRedisKey(chatID string) string {
+	if chatID == "" {
+		return "global_bans"
+	}
+	return "bans:" + chatID
+}
+
+// chatID خالی ہو تو گلوبل بین
+func saveBan(chatID string, b *BanEntry) {
+	if rdb == nil {
+		return
+	}
+	jsonData, err := json.Marshal(b)
+	if err != nil {
+		return
+	}
+	if err := rdb.HSet(ctx, banRedisKey(chatID), getCleanID(b.User), jsonData).Err(); err != nil {
+		fmt.Printf("⚠️ [REDIS ERROR] Failed to save ban: %v\n", err)
+	}
+}
+
+func removeBan(client *whatsmeow.Client, chatID string, user types.JID) bool {
+	if rdb == nil {
+		return false
+	}
+	removed := false
+	for _, alias := range getUserAliases(client, user) {
+		n, _ := rdb.HDel(ctx, banRedisKey(chatID), alias).Result()
+		if n > 0 {
+			removed = true
+		}
+	}
+	return removed
+}
+
+func listBans(chatID string) []*BanEntry {
+	if rdb == nil {
+		return nil
+	}
+	vals, err := rdb.HGetAll(ctx, banRedisKey(chatID)).Result()
+	if err != nil {
+		return nil
+	}
+	var list []*BanEntry
+	for _, val := range vals {
+		var b BanEntry
+		if json.Unmarshal([]byte(val), &b) == nil {
+			list = append(list, &b)
+		}
+	}
+	return list
+}
+
+// پہلے گروپ لسٹ، پھر گلوبل لسٹ چیک کریں (LID اور نمبر دونوں)
+func findBan(client *whatsmeow.Client, chatID string, user types.JID) *BanEntry {
+	if rdb == nil {
+		return nil
+	}
+	aliases := getUserAliases(client, user)
+	for _, key := range []string{banRedisKey(chatID), banRedisKey("")} {
+		for _, alias := range aliases {
+			val, err := rdb.HGet(ctx, key, alias).Result()
+			if err != nil {
+				continue
+			}
+			var b BanEntry
+			if json.Unmarshal([]byte(val), &b) == nil {
+				return &b
+			}
+		}
+	}
+	return nil
+}
+
+// 🚪 Join ایونٹ پر بین شدہ یوزرز کو نکالیں، باقی جوائنرز واپس کریں
+func enforceBans(client *whatsmeow.Client, v *events.GroupInfo) []types.JID {
+	var allowed []types.JID
+	for _, joined := range v.Join {
+		b := findBan(client, v.JID.String(), joined)
+		if b == nil {
+			allowed = append(allowed, joined)
+			continue
+		}
+
+		_, err := client.UpdateGroupParticipants(context.Background(), v.JID, []types.JID{joined}, whatsmeow.ParticipantChangeRemove)
+		if err != nil {
+			fmt.Printf("⚠️ [BAN] Could not remove %s from %s: %v\n", joined.User, v.JID.User, err)
+			continue
+		}
+
+		scope := "Group"
+		if b.Global {
+			scope = "Global"
+		}
+		logModAction(client, v.JID, "kick", "Banned ("+scope+"): "+b.Reason, types.EmptyJID, joined, "")
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🚫 BANNED USER
+╠════════════════╣
+║ 👤 User: @%s
+║ 🌐 Scope: %s
+║ 📝 Reason: %s
+║ 👢 Auto Removed
+╚════════════════╝`, joined.User, scope, b.Reason)
+		sendMentionText(client, v.JID, msg, []string{joined.String()})
+	}
+	return allowed
+}
+
+// ==================== کمانڈز ====================
+
+func handleBan(client *whatsmeow.Client, v *events.Message, args []string) {
+	global := len(args) > 0 && strings.ToLower(args[0]) == "global"
+	if global {
+		args = args[1:]
+		if !requireRole(client, v, RoleSudo) {
+			return
+		}
+	} else {
+		if !v.Info.IsGroup {
+			replyMessage(client, v, "❌ Group only. Use .ban global <number> in DM.")
+			return
+		}
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+	}
+
+	target, rest := resolveTarget(v, args)
+	if target.User == "" {
+		msg := `╔════════════════╗
+║ ⚠️ INVALID
+╠════════════════
+║ Usage:
+║ .ban @user [reason]
+║ .ban 92300xxx [reason]
+║ .ban global @user [reason]
+╚════════════════`
+		replyMessage(client, v, msg)
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	if getCleanID(target.User) == botID || getUserRole(client, v.Info.Chat, target) >= RoleSudo {
+		replyMessage(client, v, "❌ Cannot ban the bot, its owner or sudo users.")
+		return
+	}
+
+	reason := strings.Join(rest, " ")
+	if reason == "" {
+		reason = "No reason"
+	}
+
+	chatID := ""
+	if !global {
+		chatID = v.Info.Chat.String()
+	}
+	saveBan(chatID, &BanEntry{
+		User:     target.String(),
+		Reason:   reason,
+		By:       v.Info.Sender.User,
+		Global:   global,
+		BannedAt: time.Now(),
+	})
+
+	action := "ban"
+	if global {
+		action = "globalban"
+	}
+	logModAction(client, v.Info.Chat, action, reason, v.Info.Sender, target, "")
+
+	// اگر گروپ میں ہیں تو فوراً کک کریں
+	kicked := "—"
+	if v.Info.IsGroup {
+		_, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove)
+		if err == nil {
+			kicked = "✅"
+		} else {
+			kicked = "❌ (Not member / no rights)"
+		}
+	}
+
+	scope := "This Group"
+	if global {
+		scope = "All Groups (Global)"
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🚫 BANNED
+╠════════════════╣
+║ 👤 User: @%s
+║ 🌐 Scope: %s
+║ 📝 Reason: %s
+║ 👢 Kicked: %s
+╚════════════════╝`, target.User, scope, reason, kicked)
+	sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+}
+
+func handleUnban(client *whatsmeow.Client, v *events.Message, args []string) {
+	global := len(args) > 0 && strings.ToLower(args[0]) == "global"
+	if global {
+		args = args[1:]
+		if !requireRole(client, v, RoleSudo) {
+			return
+		}
+	} else {
+		if !v.Info.IsGroup {
+			replyMessage(client, v, "❌ Group only. Use .unban global <number> in DM.")
+			return
+		}
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+	}
+
+	target, _ := resolveTarget(v, args)
+	if target.User == "" {
+		replyMessage(client, v, "⚠️ Usage: .unban [global] @user|number")
+		return
+	}
+
+	chatID := ""
+	if !global {
+		chatID = v.Info.Chat.String()
+	}
+	if !removeBan(client, chatID, target) {
+		replyMessage(client, v, "ℹ️ This user is not on the ban list.")
+		return
+	}
+	logModAction(client, v.Info.Chat, "unban", "Manual command", v.Info.Sender, target, "")
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ✅ UNBANNED
+╠════════════════╣
+║ 👤 User: @%s
+║ 🔓 Can join again
+╚════════════════╝`, target.User)
+	sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+}
+
+func handleBanList(client *whatsmeow.Client, v *events.Message, args []string) {
+	global := len(args) > 0 && strings.ToLower(args[0]) == "global"
+	chatID := ""
+	title := "🌐 GLOBAL BANS"
+
+	if global {
+		if !requireRole(client, v, RoleSudo) {
+			return
+		}
+	} else {
+		if !v.Info.IsGroup {
+			replyMessage(client, v, "❌ Group only. Use .banlist global in DM.")
+			return
+		}
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		chatID = v.Info.Chat.String()
+		title = "🚫 GROUP BANS"
+	}
+
+	list := listBans(chatID)
+	if len(list) == 0 {
+		replyMessage(client, v, "📭 Ban list is empty.")
+		return
+	}
+
+	out := "╔════════════════╗\n"
+	out += "║ " + title + "\n"
+	out += "╠════════════════╣\n"
+	for i, b := range list {
+		out += fmt.Sprintf("║ %d. %s\n║    📝 %s\n", i+1, getCleanID(b.User), b.Reason)
+	}
+	out += fmt.Sprintf("║ 📊 Total: %d\n", len(list))
+	out += "╚════════════════╝"
+
+	replyMessage(client, v, out)
+}
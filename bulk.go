@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 👥 BULK ADD / KICK
+// ════════════════════════════════════════════════════════════════
+// .add / .kick کئی نمبرز، مینشنز یا ریپلائی شدہ CSV/TXT فائل قبول کرتے ہیں۔
+// واٹس ایپ ہر پارٹیسپنٹ کا الگ اسٹیٹس کوڈ واپس کرتا ہے، اسی سے رپورٹ بنتی ہے۔
+
+const (
+	bulkBatchSize   = 5
+	bulkBatchDelay  = 3 * time.Second
+	bulkMaxTargets  = 200
+	bulkMaxFileSize = 512 * 1024
+	bulkReportMax   = 40
+)
+
+type bulkResult struct {
+	JID    types.JID
+	Target string // نمبر یا یوزر
+	Status string // added / removed / member / privacy / notmember / invalid / failed
+	Detail string
+}
+
+var bulkStatusLabels = map[string]string{
+	"added":     "✅ Added",
+	"removed":   "👢 Removed",
+	"member":    "👥 Already member",
+	"notmember": "➖ Not in group",
+	"privacy":   "🔒 Privacy (invite sent)",
+	"invalid":   "❌ Invalid",
+	"skipped":   "🛡️ Skipped",
+	"failed":    "⚠️ Failed",
+}
+
+// "+92 300-1234567" → "923001234567" (غلط ہو تو خالی)
+func normalizePhone(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	num := b.String()
+	if len(num) < 7 || len(num) > 15 {
+		return ""
+	}
+	return num
+}
+
+// CSV/TXT: ہر خانے سے نمبر، ہیڈر جیسی بغیر ہندسوں والی لائنیں نظرانداز
+func extractPhonesFromText(text string) (nums, invalid []string) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ',' || r == ';' || r == '\t'
+	})
+	for _, f := range fields {
+		f = strings.Trim(strings.TrimSpace(f), `"'`)
+		if f == "" || !strings.ContainsAny(f, "0123456789") {
+			continue
+		}
+		if num := normalizePhone(f); num != "" {
+			nums = append(nums, num)
+		} else {
+			invalid = append(invalid, f)
+		}
+	}
+	return nums, invalid
+}
+
+func quotedBulkDocument(v *events.Message) *waProto.DocumentMessage {
+	ci := v.Message.GetExtendedTextMessage().GetContextInfo()
+	doc := ci.GetQuotedMessage().GetDocumentMessage()
+	if doc == nil {
+		return nil
+	}
+	ext := strings.ToLower(filepath.Ext(doc.GetFileName()))
+	mime := strings.ToLower(doc.GetMimetype())
+	if ext == ".csv" || ext == ".txt" || strings.HasPrefix(mime, "text/") {
+		return doc
+	}
+	return nil
+}
+
+// مینشنز + نمبرز + ریپلائی شدہ فائل (یا ریپلائی شدہ یوزر) سے ٹارگٹس
+func collectBulkTargets(client *whatsmeow.Client, v *events.Message, args []string) ([]types.JID, []string, error) {
+	var targets []types.JID
+	var invalid []string
+	seen := make(map[string]bool)
+
+	add := func(jid types.JID) {
+		if jid.User == "" || seen[jid.User] {
+			return
+		}
+		seen[jid.User] = true
+		targets = append(targets, jid)
+	}
+	addNum := func(num string) {
+		if jid, ok := parseJID(num); ok {
+			add(jid)
+		}
+	}
+
+	ci := v.Message.GetExtendedTextMessage().GetContextInfo()
+	for _, m := range ci.GetMentionedJID() {
+		if jid, err := types.ParseJID(m); err == nil {
+			add(jid)
+		}
+	}
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "@") && len(ci.GetMentionedJID()) > 0 {
+			continue // مینشن اوپر لے لیے
+		}
+		nums, bad := extractPhonesFromText(arg)
+		for _, n := range nums {
+			addNum(n)
+		}
+		invalid = append(invalid, bad...)
+	}
+
+	if doc := quotedBulkDocument(v); doc != nil {
+		if doc.GetFileLength() > bulkMaxFileSize {
+			return nil, nil, fmt.Errorf("file too large (max %d KB)", bulkMaxFileSize/1024)
+		}
+		data, err := client.Download(context.Background(), doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not download file: %v", err)
+		}
+		nums, bad := extractPhonesFromText(string(data))
+		for _, n := range nums {
+			addNum(n)
+		}
+		invalid = append(invalid, bad...)
+	} else if len(targets) == 0 && len(invalid) == 0 && ci.GetParticipant() != "" {
+		if jid, err := types.ParseJID(ci.GetParticipant()); err == nil {
+			add(jid)
+		}
+	}
+
+	if len(targets) > bulkMaxTargets {
+		return nil, nil, fmt.Errorf("too many numbers (max %d per run)", bulkMaxTargets)
+	}
+	return targets, invalid, nil
+}
+
+// واٹس ایپ کے پارٹیسپنٹ کوڈز → اسٹیٹس
+func bulkStatusFromCode(change whatsmeow.ParticipantChange, code int) string {
+	switch {
+	case code == 0 || code == 200:
+		if change == whatsmeow.ParticipantChangeAdd {
+			return "added"
+		}
+		return "removed"
+	case code == 403:
+		return "privacy"
+	case code == 409:
+		return "member"
+	case code == 404 && change == whatsmeow.ParticipantChangeRemove:
+		return "notmember"
+	case code == 404 || code == 400:
+		return "invalid"
+	}
+	return "failed"
+}
+
+func runBulkParticipants(client *whatsmeow.Client, chat types.JID, targets []types.JID, change whatsmeow.ParticipantChange) []bulkResult {
+	var results []bulkResult
+	for i := 0; i < len(targets); i += bulkBatchSize {
+		end := i + bulkBatchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batch := targets[i:end]
+
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, batch, change)
+		if err != nil {
+			for _, t := range batch {
+				results = append(results, bulkResult{JID: t, Target: t.User, Status: "failed", Detail: err.Error()})
+			}
+		} else {
+			byUser := make(map[string]types.GroupParticipant)
+			for _, p := range res {
+				byUser[p.JID.User] = p
+				if !p.PhoneNumber.IsEmpty() {
+					byUser[p.PhoneNumber.User] = p
+				}
+			}
+			for idx, t := range batch {
+				p, ok := byUser[t.User]
+				if !ok && len(res) == len(batch) {
+					p, ok = res[idx], true
+				}
+				status := "failed"
+				if ok {
+					status = bulkStatusFromCode(change, p.Error)
+				}
+				results = append(results, bulkResult{JID: t, Target: t.User, Status: status})
+			}
+		}
+
+		if end < len(targets) {
+			time.Sleep(bulkBatchDelay)
+		}
+	}
+	return results
+}
+
+// پرائیویسی کی وجہ سے ایڈ نہ ہو سکے تو DM میں انوائٹ لنک
+func sendBulkInvites(client *whatsmeow.Client, chat types.JID, results []bulkResult) {
+	var link, groupName string
+	for i, r := range results {
+		if r.Status != "privacy" {
+			continue
+		}
+		if link == "" {
+			code, err := client.GetGroupInviteLink(context.Background(), chat, false)
+			if err != nil {
+				results[i].Detail = "invite failed"
+				continue
+			}
+			link = code
+			groupName = chat.User
+			if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+				groupName = info.Name
+			}
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 📩 GROUP INVITE
+╠════════════════╣
+║ 👥 %s
+║ You couldn't be added
+║ directly. Join here:
+║ %s
+╚════════════════╝`, groupName, link)
+		if _, err := client.SendMessage(context.Background(), r.JID, buildMentionText(msg, nil)); err != nil {
+			results[i].Detail = "invite failed"
+		}
+	}
+}
+
+func sendBulkReport(client *whatsmeow.Client, v *events.Message, title string, results []bulkResult) {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	out := "╔════════════════╗\n"
+	out += "║ " + title + "\n"
+	out += "╠════════════════╣\n"
+	for i, r := range results {
+		if i >= bulkReportMax {
+			out += fmt.Sprintf("║ ... and %d more\n", len(results)-bulkReportMax)
+			break
+		}
+		line := fmt.Sprintf("║ %s — %s", r.Target, bulkStatusLabels[r.Status])
+		if r.Detail != "" {
+			line += " (" + truncateText(r.Detail, 40) + ")"
+		}
+		out += line + "\n"
+	}
+	out += "╠════════════════╣\n"
+	for _, s := range []string{"added", "removed", "member", "notmember", "privacy", "invalid", "skipped", "failed"} {
+		if counts[s] > 0 {
+			out += fmt.Sprintf("║ %s: %d\n", bulkStatusLabels[s], counts[s])
+		}
+	}
+	out += "╚════════════════╝"
+	replyMessage(client, v, out)
+}
+
+// ==================== کمانڈز ====================
+
+func handleBulkParticipants(client *whatsmeow.Client, v *events.Message, args []string, change whatsmeow.ParticipantChange) {
+	if !v.Info.IsGroup {
+		msg := `╔════════════════╗
+║ ❌ GROUP ONLY
+╠════════════════
+║ This command
+║ works only in
+║ group chats
+╚════════════════`
+		replyMessage(client, v, msg)
+		return
+	}
+
+	isAdd := change == whatsmeow.ParticipantChangeAdd
+	cmd, title, action := "kick", "👢 BULK KICK", "remove"
+	if isAdd {
+		cmd, title, action = "add", "➕ BULK ADD", "add"
+	}
+
+	targets, invalid, err := collectBulkTargets(client, v, args)
+	if err != nil {
+		replyMessage(client, v, "❌ "+err.Error())
+		return
+	}
+	if len(targets) == 0 && len(invalid) == 0 {
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ⚠️ INVALID
+╠════════════════
+║ Usage:
+║ .%s 92300xxx 92301xxx
+║ .%s @user @user
+║ Reply to a CSV/TXT
+║ file with .%s
+╚════════════════`, cmd, cmd, cmd)
+		replyMessage(client, v, msg)
+		return
+	}
+
+	var results []bulkResult
+	for _, bad := range invalid {
+		results = append(results, bulkResult{Target: bad, Status: "invalid"})
+	}
+
+	var run []types.JID
+	if isAdd {
+		// واٹس ایپ پر موجود ہی نہیں تو پہلے ہی invalid
+		var phones []string
+		for _, t := range targets {
+			if t.Server == types.DefaultUserServer {
+				phones = append(phones, "+"+t.User)
+			} else {
+				run = append(run, t)
+			}
+		}
+		if len(phones) > 0 {
+			resp, err := client.IsOnWhatsApp(context.Background(), phones)
+			if err != nil {
+				for _, t := range targets {
+					if t.Server == types.DefaultUserServer {
+						run = append(run, t)
+					}
+				}
+			}
+			for _, r := range resp {
+				if r.IsIn {
+					run = append(run, r.JID)
+				} else {
+					results = append(results, bulkResult{Target: strings.TrimPrefix(r.Query, "+"), Status: "invalid", Detail: "not on WhatsApp"})
+				}
+			}
+		}
+	} else {
+		botClean := getCleanID(client.Store.ID.User)
+		for _, t := range targets {
+			clean := getCleanID(t.User)
+			if clean == botClean || clean == getCleanID(v.Info.Sender.User) {
+				results = append(results, bulkResult{Target: t.User, Status: "skipped", Detail: "self"})
+				continue
+			}
+			if getUserRole(client, v.Info.Chat, t) >= RoleSudo {
+				results = append(results, bulkResult{Target: t.User, Status: "skipped", Detail: "protected"})
+				continue
+			}
+			if reason := guardBlocksChange(client, v.Info.Chat, v.Info.Sender, t, change); reason != "" {
+				logModAction(client, v.Info.Chat, "guard", "Blocked .kick ("+reason+")", v.Info.Sender, t, "")
+				results = append(results, bulkResult{Target: t.User, Status: "skipped", Detail: "guard: " + reason})
+				continue
+			}
+			run = append(run, t)
+		}
+	}
+
+	if len(run) > bulkBatchSize {
+		eta := time.Duration((len(run)-1)/bulkBatchSize) * bulkBatchDelay
+		replyMessage(client, v, fmt.Sprintf("⏳ Processing %d numbers in batches of %d (~%s)...", len(run), bulkBatchSize, formatDuration(eta)))
+	}
+
+	done := runBulkParticipants(client, v.Info.Chat, run, change)
+	for _, r := range done {
+		if r.Status == "added" || r.Status == "removed" {
+			logModAction(client, v.Info.Chat, action, "Manual command", v.Info.Sender, r.JID, "")
+		}
+	}
+	if isAdd {
+		sendBulkInvites(client, v.Info.Chat, done)
+	}
+
+	sendBulkReport(client, v, title, append(results, done...))
+}
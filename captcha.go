@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🧩 NEW MEMBER CAPTCHA
+// ════════════════════════════════════════════════════════════════
+// Works like the security wizard: the challenge card's message ID is the
+// session key and the newcomer has to reply to that card. Until they
+// pass, everything they post is revoked; on timeout they are kicked.
+// Redis "captcha:<msgID>" (JSON) تاکہ ری اسٹارٹ کے بعد بھی ٹائم آؤٹ پر کک ہو۔
+
+type CaptchaState struct {
+	GroupID   string    `json:"group_id"`
+	User      string    `json:"user"` // full JID of the newcomer
+	BotLID    string    `json:"bot_id"`
+	BotMsgID  string    `json:"bot_msg_id"`
+	Answer    string    `json:"answer"`
+	Attempts  int       `json:"attempts"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Aliases   []string  `json:"aliases"` // PN + LID (Join اور میسج الگ ایڈریسنگ سے آ سکتے ہیں)
+}
+
+var (
+	captchaMap     = make(map[string]*CaptchaState) // challenge msgID -> state
+	captchaPending = make(map[string]string)        // botID:chatID:alias -> challenge msgID
+	captchaMutex   sync.Mutex
+)
+
+var captchaEmojis = []string{"🍎", "🚗", "🐱", "⚽", "🌙", "🎈", "🔑", "🌵", "🍕", "🐢"}
+
+const (
+	captchaMaxAttempts = 3
+	captchaRedisGrace  = 24 * time.Hour // بوٹ بند رہا ہو تب بھی واپسی پر کک
+)
+
+func captchaUserKey(botID, chatID, user string) string {
+	return botID + ":" + chatID + ":" + getCleanID(user)
+}
+
+func captchaAliases(state *CaptchaState) []string {
+	if len(state.Aliases) == 0 {
+		return []string{getCleanID(state.User)}
+	}
+	return state.Aliases
+}
+
+// captchaMutex پکڑ کر کال کریں
+func registerCaptchaLocked(state *CaptchaState) {
+	captchaMap[state.BotMsgID] = state
+	for _, alias := range captchaAliases(state) {
+		captchaPending[captchaUserKey(state.BotLID, state.GroupID, alias)] = state.BotMsgID
+	}
+}
+
+// بھیجنے والے کا زیر التوا کیپچا، پہلے سیدھا پھر اس کے دوسرے alias سے
+func findCaptcha(client *whatsmeow.Client, botID string, chat, user types.JID) *CaptchaState {
+	captchaMutex.Lock()
+	state := captchaMap[captchaPending[captchaUserKey(botID, chat.String(), user.User)]]
+	empty := len(captchaPending) == 0
+	captchaMutex.Unlock()
+	if state != nil || empty {
+		return state // ہاٹ پاتھ: کوئی کیپچا ہی نہیں تو LID لک اپ نہ کریں
+	}
+
+	aliases := getUserAliases(client, user)
+	captchaMutex.Lock()
+	defer captchaMutex.Unlock()
+	for _, alias := range aliases {
+		if state := captchaMap[captchaPending[captchaUserKey(botID, chat.String(), alias)]]; state != nil {
+			return state
+		}
+	}
+	return nil
+}
+
+func saveCaptchaState(state *CaptchaState) {
+	if rdb == nil {
+		return
+	}
+	jsonData, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	ttl := time.Until(state.ExpiresAt) + captchaRedisGrace
+	if err := rdb.Set(ctx, "captcha:"+state.BotMsgID, jsonData, ttl).Err(); err != nil {
+		fmt.Printf("⚠️ [REDIS ERROR] Failed to save captcha: %v\n", err)
+	}
+}
+
+// میموری اور Redis دونوں سے ہٹائیں؛ false = پہلے ہی ہٹ چکا تھا
+func removeCaptchaState(state *CaptchaState) bool {
+	captchaMutex.Lock()
+	_, existed := captchaMap[state.BotMsgID]
+	delete(captchaMap, state.BotMsgID)
+	for _, alias := range captchaAliases(state) {
+		key := captchaUserKey(state.BotLID, state.GroupID, alias)
+		if captchaPending[key] == state.BotMsgID {
+			delete(captchaPending, key)
+		}
+	}
+	captchaMutex.Unlock()
+
+	if rdb != nil {
+		rdb.Del(ctx, "captcha:"+state.BotMsgID)
+	}
+	return existed
+}
+
+// ری اسٹارٹ کے بعد زیر التوا کیپچے واپس لوڈ کریں
+func loadCaptchas() {
+	if rdb == nil {
+		return
+	}
+	count := 0
+	iter := rdb.Scan(ctx, 0, "captcha:*", 100).Iterator()
+	for iter.Next(ctx) {
+		val, err := rdb.Get(ctx, iter.Val()).Result()
+		if err != nil {
+			continue
+		}
+		var state CaptchaState
+		if json.Unmarshal([]byte(val), &state) != nil {
+			continue
+		}
+		captchaMutex.Lock()
+		registerCaptchaLocked(&state)
+		captchaMutex.Unlock()
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		fmt.Printf("⚠️ [CAPTCHA] Could not load captchas: %v\n", err)
+	}
+	fmt.Printf("🧩 [CAPTCHA] %d pending captcha(s) restored from Redis\n", count)
+}
+
+// ⏱️ ٹائم آؤٹ والے ممبرز کو کک کریں (بوٹ آن لائن ہو تب)
+func startCaptchaWatcher() {
+	ticker := time.NewTicker(15 * time.Second)
+	go func() {
+		for range ticker.C {
+			now := time.Now()
+			var expired []*CaptchaState
+
+			captchaMutex.Lock()
+			for _, state := range captchaMap {
+				if now.After(state.ExpiresAt) {
+					expired = append(expired, state)
+				}
+			}
+			captchaMutex.Unlock()
+
+			for _, state := range expired {
+				clientsMutex.RLock()
+				botClient := activeClients[state.BotLID]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue // بوٹ آن لائن آنے پر
+				}
+				if !removeCaptchaState(state) {
+					continue
+				}
+				chat, ok := parseJID(state.GroupID)
+				if !ok {
+					continue
+				}
+				user, err := types.ParseJID(state.User)
+				if err != nil {
+					continue
+				}
+				kickUnverified(botClient, chat, user, "Verification timed out")
+			}
+		}
+	}()
+}
+
+// چیلنج اور جواب تیار کریں
+func newCaptchaChallenge(kind string) (string, string) {
+	if kind == "emoji" {
+		e := captchaEmojis[rand.Intn(len(captchaEmojis))]
+		return "Reply to this message with this emoji: " + e, e
+	}
+	a, b := rand.Intn(9)+1, rand.Intn(9)+1
+	return fmt.Sprintf("Reply to this message with the answer: %d + %d = ?", a, b), strconv.Itoa(a + b)
+}
+
+// 🚪 Join پر نئے ممبر کو چیلنج بھیجیں
+func startCaptcha(client *whatsmeow.Client, s *GroupSettings, chat, user types.JID) {
+	botID := getCleanID(client.Store.ID.User)
+	if getCleanID(user.User) == botID {
+		return
+	}
+
+	timeout := time.Duration(s.CaptchaTimeout) * time.Minute
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	question, answer := newCaptchaChallenge(s.CaptchaType)
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🧩 VERIFICATION
+╠════════════════╣
+║ 👤 @%s
+║ %s
+║ ⏱️ Time: %s
+║ ⚠️ Unverified = Kick
+╚════════════════╝`, user.User, question, formatDuration(timeout))
+
+	resp, err := client.SendMessage(context.Background(), chat, buildMentionText(msg, []string{user.String()}))
+	if err != nil {
+		fmt.Printf("⚠️ [CAPTCHA] Could not send challenge in %s: %v\n", chat.User, err)
+		return
+	}
+
+	state := &CaptchaState{
+		GroupID:   chat.String(),
+		User:      user.String(),
+		BotLID:    botID,
+		BotMsgID:  resp.ID,
+		Answer:    answer,
+		ExpiresAt: time.Now().Add(timeout),
+		Aliases:   getUserAliases(client, user),
+	}
+
+	captchaMutex.Lock()
+	registerCaptchaLocked(state)
+	captchaMutex.Unlock()
+	saveCaptchaState(state) // ٹائم آؤٹ startCaptchaWatcher سنبھالتا ہے
+}
+
+func kickUnverified(client *whatsmeow.Client, chat, user types.JID, reason string) {
+	_, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangeRemove)
+	if err != nil {
+		fmt.Printf("⚠️ [CAPTCHA] Could not kick %s: %v\n", user.User, err)
+		return
+	}
+	logModAction(client, chat, "kick", "Captcha: "+reason, types.EmptyJID, user, "")
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 👢 KICKED
+╠════════════════╣
+║ 👤 User: @%s
+║ 📝 Reason: %s
+╚════════════════╝`, user.User, reason)
+	sendMentionText(client, chat, msg, []string{user.String()})
+}
+
+// 🛡️ غیر تصدیق شدہ ممبر کا میسج: درست جواب = پاس، ورنہ ڈیلیٹ
+// true واپس آئے تو میسج مزید پروسیس نہ کریں
+func handleCaptchaMessage(client *whatsmeow.Client, v *events.Message) bool {
+	botID := getCleanID(client.Store.ID.User)
+	state := findCaptcha(client, botID, v.Info.Chat, v.Info.Sender)
+	if state == nil {
+		return false
+	}
+
+	// ریپلائی اسی چیلنج کارڈ پر ہونا چاہیے
+	quotedID := v.Message.GetExtendedTextMessage().GetContextInfo().GetStanzaID()
+	answer := strings.TrimSpace(getText(v.Message))
+
+	if quotedID == state.BotMsgID && answer == state.Answer {
+		removeCaptchaState(state)
+
+		client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, types.EmptyJID, state.BotMsgID))
+
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ✅ VERIFIED
+╠════════════════╣
+║ 👤 User: @%s
+║ 🎉 Welcome aboard!
+╚════════════════╝`, v.Info.Sender.User)
+		sendMentionText(client, v.Info.Chat, msg, []string{v.Info.Sender.String()})
+		return true
+	}
+
+	client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+	logModAction(client, v.Info.Chat, "delete", "Captcha: unverified member", types.EmptyJID, v.Info.Sender, getText(v.Message))
+
+	// صرف چیلنج پر غلط جواب کو کوشش شمار کریں
+	if quotedID != state.BotMsgID {
+		return true
+	}
+
+	captchaMutex.Lock()
+	state.Attempts++
+	failed := state.Attempts >= captchaMaxAttempts
+	captchaMutex.Unlock()
+
+	if !failed {
+		saveCaptchaState(state)
+	} else if removeCaptchaState(state) {
+		kickUnverified(client, v.Info.Chat, v.Info.Sender, "Failed verification")
+	}
+	return true
+}
+
+// ==================== کمانڈ ====================
+
+func handleCaptcha(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 0 {
+		status := "🔴 DISABLED"
+		if s.Captcha {
+			status = "🟢 ENABLED"
+		}
+		kind := s.CaptchaType
+		if kind == "" {
+			kind = "math"
+		}
+		timeout := s.CaptchaTimeout
+		if timeout <= 0 {
+			timeout = 5
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🧩 CAPTCHA STATUS
+╠════════════════╣
+║ Status: %s
+║ Type: %s
+║ Timeout: %d min
+╠════════════════╣
+║ .captcha on/off
+║ .captcha setup
+║ .captcha math/emoji
+║ .captcha timeout 5
+╚════════════════╝`, status, strings.ToUpper(kind), timeout)
+		replyMessage(client, v, msg)
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "setup":
+		startWizard(client, v, "captcha", botID, v.Info.Chat.String())
+		return
+	case "on":
+		s.Captcha = true
+		replyMessage(client, v, "✅ *Join Captcha:* ON")
+	case "off":
+		s.Captcha = false
+		replyMessage(client, v, "❌ *Join Captcha:* OFF")
+	case "math", "emoji":
+		s.CaptchaType = strings.ToLower(args[0])
+		replyMessage(client, v, "✅ Captcha type set to "+strings.ToUpper(s.CaptchaType))
+	case "timeout":
+		n := 0
+		if len(args) > 1 {
+			n, _ = strconv.Atoi(args[1])
+		}
+		if n < 1 || n > 60 {
+			replyMessage(client, v, "⚠️ Usage: .captcha timeout <1-60 minutes>")
+			return
+		}
+		s.CaptchaTimeout = n
+		replyMessage(client, v, fmt.Sprintf("✅ Captcha timeout set to %d min", n))
+	default:
+		replyMessage(client, v, "⚠️ Usage: .captcha on | off | setup | math | emoji | timeout <min>")
+		return
+	}
+	saveGroupSettings(botID, s)
+}
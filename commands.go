@@ -72,6 +72,18 @@ func handler(botClient *whatsmeow.Client, evt interface{}) {
 		// گروپ کی انفارمیشن چینج کو ہینڈل کریں
 		go handleGroupInfoChange(botClient, v)
 
+	case *events.Picture:
+		// 🔐 گروپ آئیکن لاک
+		go handleGroupPictureChange(botClient, v)
+
+	case *events.CallOffer:
+		// 📵 اینٹی کال
+		go handleIncomingCall(botClient, v.BasicCallMeta)
+
+	case *events.CallOfferNotice:
+		// گروپ کالز
+		go handleIncomingCall(botClient, v.BasicCallMeta)
+
 	case *events.Connected:
 		fmt.Printf("🟢 [ONLINE] Bot %s connected!\n", botClient.Store.ID.User)
 		
@@ -88,6 +100,12 @@ func isKnownCommand(text string) bool {
 		"addstatus", "delstatus", "liststatus", "readallstatus", "setprefix", "mode",
 		"antilink", "antipic", "antivideo", "antisticker",
 		"kick", "add", "promote", "demote", "tagall", "hidetag", "group", "del", "delete",
+		"mute", "unmute", "mutes", "ban", "unban", "banlist", "captcha",
+		"antiraid", "lockdown", "modlog", "antidelete", "anticall", "antimedia", "antibug", "antinsfw", "sudo", "trust", "requests", "schedule", "inactive", "purge",
+		"setwelcome", "setgoodbye", "setpromote", "setdemote", "testwelcome", "rules", "setrules",
+		"snapshot", "lock", "unlock", "restore", "guard", "announce", "top",
+		"rank", "leaderboard", "lb", "xp", "poll", "pollresult",
+		"remind", "reminders", "schedulemsg",
 		"tiktok", "tt", "fb", "facebook", "insta", "ig", "pin", "pinterest", "ytmp3", "ytmp4",
 		"sticker", "s", "toimg", "tovideo", "removebg", "remini", "tourl", "weather", "translate", "tr", "vv",
 	}
@@ -104,9 +122,11 @@ func isKnownCommand(text string) bool {
 
 
 // ⚡ PERMISSION CHECK FUNCTION (UPDATED)
-func canExecute(client *whatsmeow.Client, v *events.Message, cmd string) bool {
-	// 1. Owner Check
-	if isOwner(client, v.Info.Sender) { return true }
+// role ڈسپیچر ایک بار نکالتا ہے (checkCommandRole بھی وہی استعمال کرتا ہے)
+func canExecute(client *whatsmeow.Client, v *events.Message, cmd string, role Role) bool {
+	// 1. Owner / Sudo Check (بین شدہ یوزرز کی کمانڈز خاموشی سے اگنور)
+	if role >= RoleSudo { return true }
+	if role == RoleBanned { return false }
 	
 	// 2. Private Chat Check (Always Allowed unless blacklisted)
 	if !v.Info.IsGroup { return true }
@@ -118,7 +138,7 @@ func canExecute(client *whatsmeow.Client, v *events.Message, cmd string) bool {
 	s := getGroupSettings(botID, v.Info.Chat.String())
 	
 	if s.Mode == "private" { return false }
-	if s.Mode == "admin" { return isAdmin(client, v.Info.Chat, v.Info.Sender) }
+	if s.Mode == "admin" { return role >= RoleAdmin }
 	
 	return true
 }
@@ -139,31 +159,60 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
+	// 🛡️ Anti-Bug: کریش پے لوڈ سب سے پہلے (DM اور گروپ دونوں)
+	if scanIncomingForBugs(client, v) {
+		return
+	}
+
+	// 🔇 Muted members: ہر میسج (ٹیکسٹ یا میڈیا) فوراً ڈیلیٹ
+	if v.Info.IsGroup && enforceMute(client, v) {
+		return
+	}
+
+	// 🧩 Captcha: غیر تصدیق شدہ ممبر کے میسجز
+	if v.Info.IsGroup && handleCaptchaMessage(client, v) {
+		return
+	}
+
+	// ♻️ Anti-Delete: میسج کیشے کریں / ریووک پر دوبارہ پوسٹ
+	if v.Info.IsGroup && handleAntiDelete(client, v) {
+		return
+	}
+
+	// 💤 ایکٹیویٹی ٹریکنگ (inactive / purge کے لیے) — میوٹ/کیپچا والے میسجز شامل نہیں
+	if v.Info.IsGroup && !v.Info.IsFromMe {
+		recordActivity(v.Info.Chat, v.Info.Sender)
+		recordMessageStats(client, v)
+		awardMessageXP(client, v)
+	}
+
+	// 📥 Join request captcha کا جواب (DM)
+	if !v.Info.IsGroup && handleJoinCaptchaReply(client, v) {
+		return
+	}
+
+	// 📜 رولز میسج پر ری ایکشن/ریپلائی = منظوری
+	trackRulesAck(client, v)
+
+	// 📊 پول ووٹ (encrypted update)
+	if handlePollVote(client, v) {
+		return
+	}
+
 	// ⚡ 3. Basic Text Extraction
 	bodyRaw := getText(v.Message)
 	if bodyRaw == "" {
+		// 📎 بغیر ٹیکسٹ والا میڈیا بھی سیکیورٹی سے گزرے
+		if v.Info.IsGroup && !v.Info.IsFromMe {
+			checkSecurity(client, v)
+			return
+		}
 		if v.Info.Chat.String() != "status@broadcast" {
 			return
 		}
 	}
 	bodyClean := strings.TrimSpace(bodyRaw)
 
-	// =========================================================
-	// 🛡️ 0. IMMEDIATE ANTI-BUG PROTECTION (Private Chats Only)
-	// =========================================================
-	if AntiBugEnabled && !v.Info.IsGroup {
-		badChars := []string{"\u200b", "\u202e", "\u202d", "\u2060", "\u200f"}
-		totalJunk := 0
-		for _, char := range badChars {
-			totalJunk += strings.Count(bodyClean, char)
-		}
-		if totalJunk > 50 {
-			fmt.Printf("🛡️ MALICIOUS BUG DETECTED in DM! From: %s | Cleaning...\n", v.Info.Sender.User)
-			client.RevokeMessage(context.Background(), v.Info.Chat, v.Info.ID)
-			return 
-		}
-	}
-
 	// ⚡ 4. Bot Identity Setup
 	rawBotID := client.Store.ID.User
 	botID := strings.TrimSuffix(strings.Split(rawBotID, ":")[0], "@s.whatsapp.net")
@@ -221,13 +270,17 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		}
 
 		// 🔍 C. Session Checks (Reply Handling)
+		if cancelActiveWizard(client, v) {
+			return
+		}
+
 		var qID string
 		if extMsg := v.Message.GetExtendedTextMessage(); extMsg != nil && extMsg.ContextInfo != nil {
 			qID = extMsg.ContextInfo.GetStanzaID()
 			
 			// Setup Wizard
-			if _, ok := setupMap[qID]; ok {
-				handleSetupResponse(client, v)
+			if state := getWizardState(qID); state != nil {
+				handleSetupResponse(client, v, state)
 				return
 			}
 			// YouTube Search Menu
@@ -282,7 +335,11 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		fullArgs := strings.TrimSpace(strings.Join(words[1:], " "))
 
 		// 🛡️ E. PERMISSION CHECK (Now using Cached isAdmin)
-		if !canExecute(client, v, cmd) { return }
+		role := getUserRole(client, v.Info.Chat, v.Info.Sender)
+		if !canExecute(client, v, cmd, role) { return }
+
+		// 👑 کمانڈ کا مطلوبہ رول (roles.go ٹیبل)
+		if !checkCommandRole(client, v, cmd, role) { return }
 
 		// Log Command
 		fmt.Printf("🚀 [EXEC] Bot:%s | CMD:%s\n", botID, cmd)
@@ -292,27 +349,22 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 
 		// ✅ WELCOME TOGGLE
 		case "welcome", "wel":
-			if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-				replyMessage(client, v, "❌ Only Admins!")
-				return
-			}
 			s := getGroupSettings(botID, chatID)
+			cfg := greetConfig(s, "welcome")
 			if fullArgs == "on" || fullArgs == "enable" {
-				s.Welcome = true
+				cfg.Enabled = true
 				replyMessage(client, v, "✅ *Welcome Messages:* ON")
 			} else if fullArgs == "off" || fullArgs == "disable" {
-				s.Welcome = false
+				cfg.Enabled = false
 				replyMessage(client, v, "❌ *Welcome Messages:* OFF")
 			} else {
 				replyMessage(client, v, "⚠️ Usage: .welcome on | off")
+				return
 			}
+			setGreetConfig(s, "welcome", cfg)
 			saveGroupSettings(botID, s)
 
 		case "setprefix":
-			if !isOwner(client, v.Info.Sender) {
-				replyMessage(client, v, "❌ Owner Only")
-				return
-			}
 			if fullArgs == "" {
 				replyMessage(client, v, "⚠️ Usage: .setprefix !")
 				return
@@ -331,7 +383,7 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		case "listbots":
 			sendBotsList(client, v)
 		case "data":
-			replyMessage(client, v, "╔════════════════╗\n║ 📂 DATA STATUS\n╠════════════════╣\n║ ✅ System Active\n╚════════════════╝")
+			sendDataStatus(client, v)
 		case "alwaysonline":
 			toggleAlwaysOnline(client, v)
 		case "autoread":
@@ -347,7 +399,7 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		case "delstatus":
 			handleDelStatus(client, v, words[1:])
 		case "antibug":
-			handleAntiBug(client, v)
+			handleAntiBug(client, v, words[1:])
 		case "send":
 			handleSendBug(client, v, words[1:])
 		case "liststatus":
@@ -380,6 +432,90 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			handleGroup(client, v, words[1:])
 		case "del", "delete":
 			handleDelete(client, v)
+		case "mute":
+			handleMute(client, v, words[1:])
+		case "unmute":
+			handleUnmute(client, v, words[1:])
+		case "mutes":
+			handleMuteList(client, v)
+		case "ban":
+			handleBan(client, v, words[1:])
+		case "unban":
+			handleUnban(client, v, words[1:])
+		case "banlist":
+			handleBanList(client, v, words[1:])
+		case "captcha":
+			handleCaptcha(client, v, words[1:])
+		case "antiraid":
+			handleAntiRaid(client, v, words[1:])
+		case "lockdown":
+			handleLockdown(client, v, words[1:])
+		case "modlog":
+			handleModLog(client, v, words[1:])
+		case "antidelete":
+			handleAntiDeleteCmd(client, v, words[1:])
+		case "anticall":
+			handleAntiCall(client, v, words[1:])
+		case "antimedia":
+			handleAntiMedia(client, v, words[1:])
+		case "antinsfw":
+			handleAntiNSFW(client, v, words[1:])
+		case "sudo":
+			handleSudo(client, v, words[1:])
+		case "trust":
+			handleTrust(client, v, words[1:])
+		case "requests":
+			handleRequests(client, v, words[1:])
+		case "schedule":
+			handleSchedule(client, v, words[1:])
+		case "inactive":
+			handleInactive(client, v, words[1:])
+		case "purge":
+			handlePurge(client, v, words[1:])
+		case "setwelcome":
+			handleSetGreeting(client, v, words[1:], "welcome")
+		case "setgoodbye":
+			handleSetGreeting(client, v, words[1:], "goodbye")
+		case "setpromote":
+			handleSetGreeting(client, v, words[1:], "promote")
+		case "setdemote":
+			handleSetGreeting(client, v, words[1:], "demote")
+		case "testwelcome":
+			handleTestWelcome(client, v, words[1:])
+		case "rules":
+			handleRules(client, v, words[1:])
+		case "setrules":
+			handleSetRules(client, v, words[1:])
+		case "snapshot":
+			handleSnapshot(client, v, words[1:])
+		case "lock":
+			handleMetaLock(client, v, words[1:], true)
+		case "unlock":
+			handleMetaLock(client, v, words[1:], false)
+		case "restore":
+			handleRestore(client, v, words[1:])
+		case "guard":
+			handleGuard(client, v, words[1:])
+		case "announce":
+			handleAnnounce(client, v, words[1:])
+		case "top":
+			handleTop(client, v, words[1:])
+		case "rank":
+			handleRank(client, v, words[1:])
+		case "leaderboard", "lb":
+			handleLeaderboard(client, v, words[1:])
+		case "xp":
+			handleXP(client, v, words[1:])
+		case "poll":
+			handlePoll(client, v, words[1:])
+		case "pollresult":
+			handlePollResult(client, v, words[1:])
+		case "remind":
+			handleRemind(client, v, words[1:])
+		case "reminders":
+			handleReminders(client, v, words[1:])
+		case "schedulemsg":
+			handleScheduleMsg(client, v, words[1:])
 		
 		// 🛠️ HEAVY MEDIA COMMANDS (Already Optimized)
 		case "toimg":
@@ -475,7 +611,12 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		
 		// 🛠️ TOOLS (Medium Load)
 		case "stats", "server", "dashboard":
-			handleServerStats(client, v)
+			// گروپ میں .stats @user / me / on|off → ممبر اسٹیٹس
+			if cmd == "stats" && v.Info.IsGroup && len(words) > 1 {
+				handleMemberStats(client, v, words[1:])
+			} else {
+				handleServerStats(client, v)
+			}
 		case "speed", "speedtest":
 			handleSpeedTest(client, v)
 		case "ss", "screenshot":
@@ -684,6 +825,20 @@ func sendBotsList(client *whatsmeow.Client, v *events.Message) {
 	replyMessage(client, v, msg)
 }
 
+func sendDataStatus(client *whatsmeow.Client, v *events.Message) {
+	botID := getCleanID(client.Store.ID.User)
+	rejected, blocked := getCallStats(botID)
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 📂 DATA STATUS
+╠════════════════╣
+║ ✅ System Active
+╠════════════════╣
+║ 📵 Calls Rejected: %d
+║ 🚫 Callers Blocked: %d
+╚════════════════╝`, rejected, blocked)
+	replyMessage(client, v, msg)
+}
+
 func getFormattedUptime() string {
 	seconds := persistentUptime
 	days := seconds / 86400
@@ -759,21 +914,56 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 ║ ╰───────────────────────╯
 ║                             
 ║ ╭────── GROUP ADMIN ──────╮
-║ │ 🔸 *%sadd* - Add New Member
+║ │ 🔸 *%sadd* - Add Members / CSV
+║ │ 🔸 *%sannounce* - Scheduled Hidetag
+║ │ 🔸 *%sban* - Ban & Kick User
+║ │ 🔸 *%sbanlist* - Banned Users
+║ │ 🔸 *%scaptcha* - Join Verification
 ║ │ 🔸 *%sdemote* - Remove Admin
 ║ │ 🔸 *%sgroup* - Group Settings
-║ │ 🔸 *%shidetag* - Hidden Mention
+║ │ 🔸 *%sguard* - Anti-Demote Guard
+║ │ 🔸 *%shidetag* - Hidden Mention / Filters
+║ │ 🔸 *%sinactive* - Silent Members
 ║ │ 🔸 *%skick* - Remove Member    
+║ │ 🔸 *%sleaderboard* - XP Ranking
+║ │ 🔸 *%slock* - Lock Name/Desc/Icon
+║ │ 🔸 *%slockdown* - Lock Group Now
+║ │ 🔸 *%smodlog* - Moderation Log
+║ │ 🔸 *%smute* - Timed Mute
+║ │ 🔸 *%sunmute* - Lift Mute
+║ │ 🔸 *%smutes* - Muted List
+║ │ 🔸 *%spoll* - Native Poll
+║ │ 🔸 *%spollresult* - Poll Tally
 ║ │ 🔸 *%spromote* - Make Admin
-║ │ 🔸 *%stagall* - Mention Everyone
+║ │ 🔸 *%spurge* - Kick Inactive
+║ │ 🔸 *%srank* - XP Rank Card
+║ │ 🔸 *%srequests* - Join Requests
+║ │ 🔸 *%srestore* - Restore Snapshot
+║ │ 🔸 *%srules* - Group Rules
+║ │ 🔸 *%sschedule* - Auto Open/Close
+║ │ 🔸 *%ssetgoodbye* - Goodbye Template
+║ │ 🔸 *%ssetrules* - Edit Rules
+║ │ 🔸 *%ssetwelcome* - Welcome Template
+║ │ 🔸 *%ssnapshot* - Save Group Info
+║ │ 🔸 *%stagall* - Mention All / Filters
+║ │ 🔸 *%stop* - Most Active Members
+║ │ 🔸 *%strust* - Trusted Members
+║ │ 🔸 *%sunban* - Remove Ban
 ║ │ 🔸 *%swelcome* - Welcome on/off
+║ │ 🔸 *%sxp* - Levels & Rewards
 ║ ╰───────────────────────╯
 ║                             
 ║ ╭──── BOT SETTINGS ─────╮
 ║ │ 🔸 *%ssetprefix* - Reply Symbol
 ║ │ 🔸 *%saddstatus* - Auto Status
 ║ │ 🔸 *%salwaysonline* - Online 24/7
+║ │ 🔸 *%santibug* - Crash Protection
+║ │ 🔸 *%santicall* - Reject Calls
+║ │ 🔸 *%santidelete* - Re-post Deleted
 ║ │ 🔸 *%santilink* - Link Protection
+║ │ 🔸 *%santimedia* - Media Type Rules
+║ │ 🔸 *%santinsfw* - Explicit Image Filter
+║ │ 🔸 *%santiraid* - Raid Lockdown
 ║ │ 🔸 *%santipic* - No Images Mode
 ║ │ 🔸 *%santisticker* - No Stickers
 ║ │ 🔸 *%santivideo* - No Video Mode
@@ -783,10 +973,11 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 ║ │ 🔸 *%sdelstatus* - Remove Status
 ║ │ 🔸 *%smode* - Private/Public
 ║ │ 🔸 *%sstatusreact* - React Status
+║ │ 🔸 *%ssudo* - Owner-Level Users
 ║ ╰────────────────────────╯
 ║                             
 ║ ╭────── AI & TOOLS ─────────╮
-║ │ 🔸 *%sstats* - Server Dashboard
+║ │ 🔸 *%sstats* - Server / Member Stats
 ║ │ 🔸 *%sspeed* - Internet Speed
 ║ │ 🔸 *%sss* - Web Screenshot
 ║ │ 🔸 *%sai* - Artificial Intelligence
@@ -795,6 +986,9 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 ║ │ 🔸 *%simg* - Image Generator 
 ║ │ 🔸 *%sgoogle* - Fast Search
 ║ │ 🔸 *%sweather* - Climate Info
+║ │ 🔸 *%sremind* - Personal Reminder
+║ │ 🔸 *%sreminders* - List / Cancel
+║ │ 🔸 *%sschedulemsg* - Recurring Message
 ║ │ 🔸 *%sremini* - HD Image Upscaler
 ║ │ 🔸 *%sremovebg* - Background Eraser
 ║ │ 🔸 *%sfancy* - Stylish Text
@@ -819,12 +1013,12 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 		p, p, p, p, p, p, p, p, p, p,
 		// میوزک (8)
 		p, p, p, p, p, p, p, p,
-		// گروپ (8) -> welcome شامل کر دیا
-		p, p, p, p, p, p, p, p,
-		// سیٹنگز (13) -> statusreact شامل کر دیا
-		p, p, p, p, p, p, p, p, p, p, p, p, p,
-		// ٹولز (21)
-		p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p)
+		// گروپ (37) -> mute, unmute, mutes, ban, banlist, unban, captcha, lockdown, modlog, trust, requests, schedule, inactive, purge, setwelcome, setgoodbye, rules, setrules, lock, restore, snapshot, guard, announce, top, leaderboard, rank, xp, poll, pollresult شامل
+		p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p,
+		// سیٹنگز (20) -> statusreact, antiraid, antidelete, anticall, antimedia, antibug, antinsfw, sudo شامل
+		p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p,
+		// ٹولز (24) -> remind, reminders, schedulemsg شامل
+		p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p)
 
 	// ✅ 3. تصویر کے ساتھ بھیجیں
 	imgData, err := os.ReadFile("pic.png")
@@ -961,6 +1155,14 @@ func replyMessage(client *whatsmeow.Client, v *events.Message, text string) {
 	})
 }
 
+// 📩 بوٹ اونر کو DM (اونر = بوٹ کا اپنا اکاؤنٹ، "Message Yourself" چیٹ)
+func notifyOwner(client *whatsmeow.Client, text string) {
+	if client.Store.ID == nil { return }
+	client.SendMessage(context.Background(), client.Store.ID.ToNonAD(), &waProto.Message{
+		Conversation: proto.String(text),
+	})
+}
+
 func sendReplyMessage(client *whatsmeow.Client, v *events.Message, text string) {
 	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
 		ExtendedTextMessage: &waProto.ExtendedTextMessage{
@@ -983,10 +1185,6 @@ func getText(m *waProto.Message) string {
 }
 
 func handleSessionDelete(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !isOwner(client, v.Info.Sender) {
-		replyMessage(client, v, "╔═══════════════════╗\n║ 👑 OWNER ONLY      \n╠═══════════════════╣\n║ You don't have    \n║ permission.       \n╚═══════════════════╝")
-		return
-	}
 	if len(args) == 0 {
 		replyMessage(client, v, "⚠️ Please provide a number.")
 		return
@@ -1018,6 +1216,37 @@ func handleSessionDelete(client *whatsmeow.Client, v *events.Message, args []str
 	replyMessage(client, v, msg)
 }
 
+// ⏱️ "30m", "2h", "1d", "1w" جیسے آرگیومنٹ کو Duration میں بدلیں
+func parseDurationArg(arg string) (time.Duration, bool) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if len(arg) < 2 { return 0, false }
+
+	var n int
+	if _, err := fmt.Sscanf(arg[:len(arg)-1], "%d", &n); err != nil || n <= 0 {
+		return 0, false
+	}
+
+	switch arg[len(arg)-1] {
+	case 's': return time.Duration(n) * time.Second, true
+	case 'm': return time.Duration(n) * time.Minute, true
+	case 'h': return time.Duration(n) * time.Hour, true
+	case 'd': return time.Duration(n) * 24 * time.Hour, true
+	case 'w': return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	if days > 0 { return fmt.Sprintf("%dd %dh", days, hours) }
+	if hours > 0 { return fmt.Sprintf("%dh %dm", hours, minutes) }
+	if minutes > 0 { return fmt.Sprintf("%dm", minutes) }
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
 func parseJID(arg string) (types.JID, bool) {
 	if arg == "" { return types.EmptyJID, false }
 	if !strings.Contains(arg, "@") { arg += "@s.whatsapp.net" }
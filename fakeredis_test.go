@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🧪 FAKE REDIS (ٹیسٹس کے لیے)
+// ════════════════════════════════════════════════════════════════
+// صرف وہی کمانڈز جو بوٹ استعمال کرتا ہے، RESP2 پر۔ TTL نظر انداز۔
+
+type fakeRedis struct {
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]bool
+	hashes  map[string]map[string]string
+	lists   map[string][]string
+	zsets   map[string]map[string]float64
+}
+
+// rdb کو fake سے بدلتا ہے؛ ٹیسٹ ختم ہونے پر پرانا واپس
+func startFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("fake redis: %v", err)
+	}
+	f := &fakeRedis{
+		strings: make(map[string]string),
+		sets:    make(map[string]map[string]bool),
+		hashes:  make(map[string]map[string]string),
+		lists:   make(map[string][]string),
+		zsets:   make(map[string]map[string]float64),
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	prev := rdb
+	rdb = redis.NewClient(&redis.Options{Addr: ln.Addr().String(), Protocol: 2, DisableIdentity: true})
+	t.Cleanup(func() {
+		rdb.Close()
+		rdb = prev
+		ln.Close()
+	})
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readRESP(r)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		out := f.exec(args)
+		f.mu.Unlock()
+		if _, err := conn.Write([]byte(out)); err != nil {
+			return
+		}
+	}
+}
+
+func readRESP(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		head, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(head[1:]))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func respInt(n int) string      { return ":" + strconv.Itoa(n) + "\r\n" }
+func respBulk(s string) string  { return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n" }
+func respNil() string           { return "$-1\r\n" }
+func respOK() string            { return "+OK\r\n" }
+func respErr(msg string) string { return "-ERR " + msg + "\r\n" }
+func respBool(b bool) string {
+	if b {
+		return respInt(1)
+	}
+	return respInt(0)
+}
+
+func respArray(items []string) string {
+	out := "*" + strconv.Itoa(len(items)) + "\r\n"
+	for _, it := range items {
+		out += respBulk(it)
+	}
+	return out
+}
+
+func (f *fakeRedis) del(key string) bool {
+	_, a := f.strings[key]
+	_, b := f.sets[key]
+	_, c := f.hashes[key]
+	_, d := f.lists[key]
+	_, e := f.zsets[key]
+	delete(f.strings, key)
+	delete(f.sets, key)
+	delete(f.hashes, key)
+	delete(f.lists, key)
+	delete(f.zsets, key)
+	return a || b || c || d || e
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	if len(args) == 0 {
+		return respErr("empty command")
+	}
+	cmd, a := strings.ToUpper(args[0]), args[1:]
+	switch cmd {
+	case "PING":
+		return "+PONG\r\n"
+	case "CLIENT", "SELECT", "EXPIRE", "PEXPIRE":
+		if cmd == "EXPIRE" || cmd == "PEXPIRE" {
+			return respInt(1)
+		}
+		return respOK()
+
+	case "GET":
+		if v, ok := f.strings[a[0]]; ok {
+			return respBulk(v)
+		}
+		return respNil()
+	case "SET":
+		nx := false
+		for _, opt := range a[2:] {
+			if strings.EqualFold(opt, "NX") {
+				nx = true
+			}
+		}
+		if _, exists := f.strings[a[0]]; nx && exists {
+			return respNil()
+		}
+		f.strings[a[0]] = a[1]
+		return respOK()
+	case "SETNX":
+		if _, exists := f.strings[a[0]]; exists {
+			return respInt(0)
+		}
+		f.strings[a[0]] = a[1]
+		return respInt(1)
+	case "INCR", "INCRBY":
+		by := 1
+		if cmd == "INCRBY" {
+			by, _ = strconv.Atoi(a[1])
+		}
+		n, _ := strconv.Atoi(f.strings[a[0]])
+		n += by
+		f.strings[a[0]] = strconv.Itoa(n)
+		return respInt(n)
+	case "DEL", "UNLINK":
+		n := 0
+		for _, k := range a {
+			if f.del(k) {
+				n++
+			}
+		}
+		return respInt(n)
+	case "EXISTS":
+		n := 0
+		for _, k := range a {
+			_, s1 := f.strings[k]
+			_, s2 := f.sets[k]
+			_, s3 := f.hashes[k]
+			_, s4 := f.lists[k]
+			_, s5 := f.zsets[k]
+			if s1 || s2 || s3 || s4 || s5 {
+				n++
+			}
+		}
+		return respInt(n)
+
+	case "SADD":
+		if f.sets[a[0]] == nil {
+			f.sets[a[0]] = make(map[string]bool)
+		}
+		n := 0
+		for _, m := range a[1:] {
+			if !f.sets[a[0]][m] {
+				f.sets[a[0]][m] = true
+				n++
+			}
+		}
+		return respInt(n)
+	case "SREM":
+		n := 0
+		for _, m := range a[1:] {
+			if f.sets[a[0]][m] {
+				delete(f.sets[a[0]], m)
+				n++
+			}
+		}
+		return respInt(n)
+	case "SISMEMBER":
+		return respBool(f.sets[a[0]][a[1]])
+	case "SMEMBERS":
+		var out []string
+		for m := range f.sets[a[0]] {
+			out = append(out, m)
+		}
+		sort.Strings(out)
+		return respArray(out)
+	case "SCARD":
+		return respInt(len(f.sets[a[0]]))
+
+	case "HSET":
+		if f.hashes[a[0]] == nil {
+			f.hashes[a[0]] = make(map[string]string)
+		}
+		n := 0
+		for i := 1; i+1 < len(a); i += 2 {
+			if _, ok := f.hashes[a[0]][a[i]]; !ok {
+				n++
+			}
+			f.hashes[a[0]][a[i]] = a[i+1]
+		}
+		return respInt(n)
+	case "HGET":
+		if v, ok := f.hashes[a[0]][a[1]]; ok {
+			return respBulk(v)
+		}
+		return respNil()
+	case "HDEL":
+		n := 0
+		for _, k := range a[1:] {
+			if _, ok := f.hashes[a[0]][k]; ok {
+				delete(f.hashes[a[0]], k)
+				n++
+			}
+		}
+		return respInt(n)
+	case "HEXISTS":
+		_, ok := f.hashes[a[0]][a[1]]
+		return respBool(ok)
+	case "HGETALL":
+		var out []string
+		for k, v := range f.hashes[a[0]] {
+			out = append(out, k, v)
+		}
+		return respArray(out)
+	case "HINCRBY":
+		if f.hashes[a[0]] == nil {
+			f.hashes[a[0]] = make(map[string]string)
+		}
+		by, _ := strconv.Atoi(a[2])
+		n, _ := strconv.Atoi(f.hashes[a[0]][a[1]])
+		n += by
+		f.hashes[a[0]][a[1]] = strconv.Itoa(n)
+		return respInt(n)
+
+	case "LPUSH":
+		for _, v := range a[1:] {
+			f.lists[a[0]] = append([]string{v}, f.lists[a[0]]...)
+		}
+		return respInt(len(f.lists[a[0]]))
+	case "LRANGE", "LTRIM":
+		list := f.lists[a[0]]
+		start, _ := strconv.Atoi(a[1])
+		stop, _ := strconv.Atoi(a[2])
+		if stop < 0 || stop >= len(list) {
+			stop = len(list) - 1
+		}
+		var part []string
+		if start < len(list) && start <= stop {
+			part = append(part, list[start:stop+1]...)
+		}
+		if cmd == "LTRIM" {
+			f.lists[a[0]] = part
+			return respOK()
+		}
+		return respArray(part)
+
+	case "ZADD":
+		if f.zsets[a[0]] == nil {
+			f.zsets[a[0]] = make(map[string]float64)
+		}
+		n := 0
+		for i := 1; i+1 < len(a); i += 2 {
+			score, _ := strconv.ParseFloat(a[i], 64)
+			if _, ok := f.zsets[a[0]][a[i+1]]; !ok {
+				n++
+			}
+			f.zsets[a[0]][a[i+1]] = score
+		}
+		return respInt(n)
+	case "ZSCORE":
+		if s, ok := f.zsets[a[0]][a[1]]; ok {
+			return respBulk(strconv.FormatFloat(s, 'f', -1, 64))
+		}
+		return respNil()
+	case "ZREM":
+		n := 0
+		for _, m := range a[1:] {
+			if _, ok := f.zsets[a[0]][m]; ok {
+				delete(f.zsets[a[0]], m)
+				n++
+			}
+		}
+		return respInt(n)
+	case "ZCARD":
+		return respInt(len(f.zsets[a[0]]))
+
+	case "SCAN":
+		// ایک ہی بار میں سب (cursor 0)
+		match := "*"
+		for i := 1; i+1 < len(a); i++ {
+			if strings.EqualFold(a[i], "MATCH") {
+				match = a[i+1]
+			}
+		}
+		var keys []string
+		seen := make(map[string]bool)
+		collect := func(k string) {
+			if !seen[k] && globMatch(match, k) {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+		for k := range f.strings {
+			collect(k)
+		}
+		for k := range f.sets {
+			collect(k)
+		}
+		for k := range f.hashes {
+			collect(k)
+		}
+		for k := range f.lists {
+			collect(k)
+		}
+		for k := range f.zsets {
+			collect(k)
+		}
+		sort.Strings(keys)
+		return "*2\r\n" + respBulk("0") + respArray(keys)
+	}
+	return respErr("unknown command " + cmd)
+}
+
+// صرف آخر میں * والے پیٹرن
+func globMatch(pattern, key string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(key, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == key
+}
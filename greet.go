@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 👋 WELCOME / GOODBYE / PROMOTE / DEMOTE TEMPLATES
+// ════════════════════════════════════════════════════════════════
+// ہر گروپ کا اپنا ٹیمپلیٹ اور ٹوگل GroupSettings.Greetings میں۔
+// اٹیچ کی گئی تصویر/ویڈیو Redis "greet_media:<botID>:<chatID>:<event>" میں۔
+// Placeholders: {user} {group} {count} {desc} {date} {by}
+//
+// پرانے گروپس (Greetings خالی) میں .welcome on چاروں نوٹس آن کرتا تھا،
+// اس لیے جس ایونٹ کی کنفگ نہ ہو وہ s.Welcome کو فالو کرتا ہے۔ پہلی بار کچھ
+// لکھتے وقت یہ پرانی ویلیو چاروں ایونٹس میں کاپی ہو جاتی ہے، اس کے بعد
+// ہر ایونٹ کا ٹوگل الگ ہے۔
+
+type GreetConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Template string `json:"template"`
+	Media    string `json:"media"` // "" / image / video / pp
+}
+
+const greetMediaMaxSize = 8 * 1024 * 1024
+
+var greetEvents = []string{"welcome", "goodbye", "promote", "demote"}
+
+var defaultGreetTemplates = map[string]string{
+	"welcome": `╔════════════════╗
+║ 👋 WELCOME
+╠════════════════╣
+║ 👤 User: {user}
+║ 🎉 Enjoy here!
+╚════════════════╝`,
+	"goodbye": `╔════════════════╗
+║ 👋 GOODBYE
+╠════════════════╣
+║ 👤 User: {user}
+║ 📉 Status: Left
+╚════════════════╝`,
+	"kicked": `╔════════════════╗
+║ 👢 KICKED
+╠════════════════╣
+║ 👤 User: {user}
+║ 👮 By: {by}
+╚════════════════╝`,
+	"promote": `╔════════════════╗
+║ 👑 PROMOTED
+╠════════════════╣
+║ 👤 User: {user}
+║ 🎉 New Admin!
+╚════════════════╝`,
+	"demote": `╔════════════════╗
+║ 👤 DEMOTED
+╠════════════════╣
+║ 👤 User: {user}
+║ 📉 Admin Removed
+╚════════════════╝`,
+}
+
+func greetConfig(s *GroupSettings, event string) GreetConfig {
+	if cfg, ok := s.Greetings[event]; ok {
+		return cfg
+	}
+	return GreetConfig{Enabled: s.Welcome}
+}
+
+func setGreetConfig(s *GroupSettings, event string, cfg GreetConfig) {
+	if s.Greetings == nil {
+		s.Greetings = make(map[string]GreetConfig)
+	}
+	for _, e := range greetEvents {
+		if _, ok := s.Greetings[e]; !ok {
+			s.Greetings[e] = GreetConfig{Enabled: s.Welcome}
+		}
+	}
+	s.Greetings[event] = cfg
+}
+
+func greetMediaKey(botID, chatID, event string) string {
+	return "greet_media:" + botID + ":" + chatID + ":" + event
+}
+
+func renderGreeting(tmpl string, info *types.GroupInfo, user, by types.JID) (string, []string) {
+	name, desc, count := "", "", 0
+	if info != nil {
+		name, desc, count = info.Name, info.Topic, len(info.Participants)
+	}
+	mentions := []string{user.String()}
+	byText := "Admin"
+	if !by.IsEmpty() {
+		byText = "@" + by.User
+		mentions = append(mentions, by.String())
+	}
+	text := strings.NewReplacer(
+		"{user}", "@"+user.User,
+		"{group}", name,
+		"{count}", fmt.Sprintf("%d", count),
+		"{desc}", desc,
+		"{date}", time.Now().Format("02 Jan 2006"),
+		"{by}", byText,
+	).Replace(tmpl)
+	return text, mentions
+}
+
+func fetchProfilePicture(client *whatsmeow.Client, user types.JID) []byte {
+	pic, err := client.GetProfilePictureInfo(context.Background(), user, &whatsmeow.GetProfilePictureParams{})
+	if err != nil || pic == nil || pic.URL == "" {
+		return nil
+	}
+	resp, err := http.Get(pic.URL)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, greetMediaMaxSize))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// میڈیا ہو تو کیپشن کے ساتھ، ورنہ سادہ ٹیکسٹ
+func sendGreeting(client *whatsmeow.Client, chat types.JID, cfg GreetConfig, event, text string, mentions []string, user types.JID) {
+	ci := &waProto.ContextInfo{MentionedJID: mentions}
+
+	var data []byte
+	switch cfg.Media {
+	case "pp":
+		data = fetchProfilePicture(client, user)
+	case "image", "video":
+		if rdb != nil {
+			data, _ = rdb.Get(ctx, greetMediaKey(getCleanID(client.Store.ID.User), chat.String(), event)).Bytes()
+		}
+	}
+
+	if len(data) > 0 {
+		if cfg.Media == "video" {
+			if up, err := client.Upload(context.Background(), data, whatsmeow.MediaVideo); err == nil {
+				client.SendMessage(context.Background(), chat, &waProto.Message{
+					VideoMessage: &waProto.VideoMessage{
+						Caption:       proto.String(text),
+						URL:           proto.String(up.URL),
+						DirectPath:    proto.String(up.DirectPath),
+						MediaKey:      up.MediaKey,
+						Mimetype:      proto.String("video/mp4"),
+						FileEncSHA256: up.FileEncSHA256,
+						FileSHA256:    up.FileSHA256,
+						FileLength:    proto.Uint64(uint64(len(data))),
+						ContextInfo:   ci,
+					},
+				})
+				return
+			}
+		} else if up, err := client.Upload(context.Background(), data, whatsmeow.MediaImage); err == nil {
+			client.SendMessage(context.Background(), chat, &waProto.Message{
+				ImageMessage: &waProto.ImageMessage{
+					Caption:       proto.String(text),
+					URL:           proto.String(up.URL),
+					DirectPath:    proto.String(up.DirectPath),
+					MediaKey:      up.MediaKey,
+					Mimetype:      proto.String("image/jpeg"),
+					FileEncSHA256: up.FileEncSHA256,
+					FileSHA256:    up.FileSHA256,
+					FileLength:    proto.Uint64(uint64(len(data))),
+					ContextInfo:   ci,
+				},
+			})
+			return
+		}
+	}
+
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: ci,
+		},
+	})
+}
+
+// handleGroupInfoChange سے: ایک ایونٹ کے تمام یوزرز کو نوٹس
+func sendGroupNotices(client *whatsmeow.Client, s *GroupSettings, v *events.GroupInfo) {
+	type notice struct {
+		event string
+		users []types.JID
+	}
+	var pending []notice
+	for _, n := range []notice{{"goodbye", v.Leave}, {"promote", v.Promote}, {"demote", v.Demote}, {"welcome", v.Join}} {
+		if len(n.users) > 0 && greetConfig(s, n.event).Enabled {
+			pending = append(pending, n)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	info, _ := client.GetGroupInfo(context.Background(), v.JID)
+
+	for _, n := range pending {
+		cfg := greetConfig(s, n.event)
+		for _, user := range n.users {
+			tmpl := cfg.Template
+			var by types.JID
+			if n.event == "goodbye" && v.Sender != nil && v.Sender.User != user.User {
+				by = *v.Sender
+				if tmpl == "" {
+					tmpl = defaultGreetTemplates["kicked"]
+				}
+			}
+			if tmpl == "" {
+				tmpl = defaultGreetTemplates[n.event]
+			}
+			text, mentions := renderGreeting(tmpl, info, user, by)
+			sendGreeting(client, v.JID, cfg, n.event, text, mentions, user)
+			time.Sleep(500 * time.Millisecond) // چھوٹا سا وقفہ تاکہ واٹس ایپ بین نہ کرے
+		}
+	}
+}
+
+// ==================== کمانڈز ====================
+
+// کمانڈ کے بعد کا اصل ٹیکسٹ (لائن بریکس سمیت)
+func commandText(v *events.Message) string {
+	body := strings.TrimSpace(getText(v.Message))
+	idx := strings.IndexAny(body, " \n")
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(body[idx:])
+}
+
+// .setwelcome / .setgoodbye / .setpromote / .setdemote
+func handleSetGreeting(client *whatsmeow.Client, v *events.Message, args []string, event string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+	cfg := greetConfig(s, event)
+	label := strings.ToUpper(event)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// ریپلائی شدہ تصویر/ویڈیو اٹیچ کریں
+	quoted := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage()
+	if img, vid := quoted.GetImageMessage(), quoted.GetVideoMessage(); img != nil || vid != nil {
+		var media whatsmeow.DownloadableMessage = img
+		cfg.Media = "image"
+		if vid != nil {
+			media, cfg.Media = vid, "video"
+		}
+		if rdb == nil {
+			replyMessage(client, v, "❌ Database not connected.")
+			return
+		}
+		data, err := client.Download(context.Background(), media)
+		if err != nil || len(data) > greetMediaMaxSize {
+			replyMessage(client, v, fmt.Sprintf("❌ Could not save media (max %d MB).", greetMediaMaxSize/1024/1024))
+			return
+		}
+		rdb.Set(ctx, greetMediaKey(botID, chatID, event), data, 0)
+		if text := commandText(v); text != "" {
+			cfg.Template = text
+		}
+		cfg.Enabled = true
+		setGreetConfig(s, event, cfg)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %s %s attached.", label, cfg.Media))
+		return
+	}
+
+	switch sub {
+	case "on", "off":
+		cfg.Enabled = sub == "on"
+		setGreetConfig(s, event, cfg)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ *%s Notice:* %s", label, strings.ToUpper(sub)))
+
+	case "pp":
+		cfg.Media = "pp"
+		setGreetConfig(s, event, cfg)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %s will use the member's profile picture.", label))
+
+	case "nomedia":
+		cfg.Media = ""
+		if rdb != nil {
+			rdb.Del(ctx, greetMediaKey(botID, chatID, event))
+		}
+		setGreetConfig(s, event, cfg)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %s media removed.", label))
+
+	case "reset":
+		cfg.Template = ""
+		setGreetConfig(s, event, cfg)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %s template reset to default.", label))
+
+	case "":
+		status := "🔴 OFF"
+		if cfg.Enabled {
+			status = "🟢 ON"
+		}
+		media := cfg.Media
+		if media == "" {
+			media = "None"
+		}
+		tmpl := cfg.Template
+		if tmpl == "" {
+			tmpl = "(default)"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 👋 %s SETUP
+╠════════════════╣
+║ Status: %s
+║ Media: %s
+╠════════════════╣
+║ .set%s <text>
+║ .set%s on/off
+║ .set%s pp | nomedia
+║ .set%s reset
+║ Reply to image/video
+║ to attach it
+║ Vars: {user} {group} {count}
+║ {desc} {date} {by}
+╚════════════════╝
+%s`, label, status, media, event, event, event, event, tmpl)
+		replyMessage(client, v, msg)
+
+	default:
+		cfg.Template = commandText(v)
+		cfg.Enabled = true
+		setGreetConfig(s, event, cfg)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %s template saved. Try .testwelcome %s", label, event))
+	}
+}
+
+// .testwelcome [goodbye|promote|demote] → بھیجنے والے پر پری ویو
+func handleTestWelcome(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	event := "welcome"
+	if len(args) > 0 {
+		event = strings.ToLower(args[0])
+	}
+	if _, ok := defaultGreetTemplates[event]; !ok || event == "kicked" {
+		replyMessage(client, v, "⚠️ Usage: .testwelcome ["+strings.Join(greetEvents, "|")+"]")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	cfg := greetConfig(s, event)
+	tmpl := cfg.Template
+	if tmpl == "" {
+		tmpl = defaultGreetTemplates[event]
+	}
+
+	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	text, mentions := renderGreeting(tmpl, info, v.Info.Sender, types.EmptyJID)
+	sendGreeting(client, v.Info.Chat, cfg, event, text, mentions, v.Info.Sender)
+}
@@ -13,58 +13,11 @@ import (
 )
 
 func handleKick(client *whatsmeow.Client, v *events.Message, args []string) {
-	groupAction(client, v, args, "remove")
+	handleBulkParticipants(client, v, args, whatsmeow.ParticipantChangeRemove)
 }
 
 func handleAdd(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !v.Info.IsGroup {
-		msg := `╔════════════════╗
-║ ❌ GROUP ONLY
-╠════════════════
-║ This command
-║ works only in
-║ group chats
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ DENIED
-╠════════════════
-║ 🔒 Admin Only
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	if len(args) == 0 {
-		msg := `╔════════════════╗
-║ ⚠️ INVALID
-╠════════════════
-║ Usage:
-║ .add <number>
-║
-║ Example:
-║ .add 92300xxx
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	num := strings.ReplaceAll(args[0], "+", "")
-	jid, _ := types.ParseJID(num + "@s.whatsapp.net")
-	client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{jid}, whatsmeow.ParticipantChangeAdd)
-
-	msg := fmt.Sprintf(`╔════════════════╗
-║ ✅ ADDED
-╠════════════════
-║ Number: %s
-║ Added to group
-╚════════════════`, args[0])
-
-	replyMessage(client, v, msg)
+	handleBulkParticipants(client, v, args, whatsmeow.ParticipantChangeAdd)
 }
 
 func handlePromote(client *whatsmeow.Client, v *events.Message, args []string) {
@@ -75,104 +28,6 @@ func handleDemote(client *whatsmeow.Client, v *events.Message, args []string) {
 	groupAction(client, v, args, "demote")
 }
 
-func handleTagAll(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !v.Info.IsGroup {
-		msg := `╔════════════════╗
-║ ❌ GROUP ONLY
-╠════════════════
-║ This command
-║ works only in
-║ group chats
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ DENIED
-╠════════════════
-║ 🔒 Admin Only
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
-	mentions := []string{}
-	out := "╔════════════════╗\n"
-	out += "║ 📣 TAG ALL\n"
-	out += "╠════════════════\n"
-
-	if len(args) > 0 {
-		out += "║ 💬 " + strings.Join(args, " ") + "\n"
-	}
-
-	for _, p := range info.Participants {
-		mentions = append(mentions, p.JID.String())
-		out += "║ @" + p.JID.User + "\n"
-	}
-
-	out += fmt.Sprintf("║ 👥 Total: %d\n", len(info.Participants))
-	out += "╚════════════════"
-
-	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		ExtendedTextMessage: &waProto.ExtendedTextMessage{
-			Text: proto.String(out),
-			ContextInfo: &waProto.ContextInfo{
-				MentionedJID: mentions,
-				StanzaID:     proto.String(v.Info.ID),
-				Participant:  proto.String(v.Info.Sender.String()),
-			},
-		},
-	})
-}
-
-func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !v.Info.IsGroup {
-		msg := `╔════════════════╗
-║ ❌ GROUP ONLY
-╠════════════════
-║ This command
-║ works only in
-║ group chats
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ DENIED
-╠════════════════
-║ 🔒 Admin Only
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
-	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
-	mentions := []string{}
-	text := strings.Join(args, " ")
-
-	if text == "" {
-		text = "🔔 Hidden Tag"
-	}
-
-	for _, p := range info.Participants {
-		mentions = append(mentions, p.JID.String())
-	}
-
-	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		ExtendedTextMessage: &waProto.ExtendedTextMessage{
-			Text: proto.String(text),
-			ContextInfo: &waProto.ContextInfo{
-				MentionedJID: mentions,
-			},
-		},
-	})
-}
-
 func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 	if !v.Info.IsGroup {
 		msg := `╔════════════════╗
@@ -186,16 +41,6 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ DENIED
-╠════════════════
-║ 🔒 Admin Only
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	if len(args) == 0 {
 		msg := `╔════════════════╗
 ║ ⚙️ SETTINGS
@@ -278,16 +123,6 @@ func handleDelete(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ DENIED
-╠════════════════
-║ 🔒 Admin Only
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	if v.Message.ExtendedTextMessage == nil {
 		msg := `╔════════════════╗
 ║ ⚠️ INVALID
@@ -306,6 +141,10 @@ func handleDelete(client *whatsmeow.Client, v *events.Message) {
 	}
 
 	client.RevokeMessage(context.Background(), v.Info.Chat, *ctx.StanzaID)
+	if ctx.Participant != nil {
+		target, _ := types.ParseJID(*ctx.Participant)
+		logModAction(client, v.Info.Chat, "delete", "Manual command", v.Info.Sender, target, getText(ctx.GetQuotedMessage()))
+	}
 
 	msg := `╔════════════════╗
 ║ 🗑️ DELETED
@@ -328,16 +167,6 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 		return
 	}
 
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ DENIED
-╠════════════════
-║ 🔒 Admin Only
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	var targetJID types.JID
 	if len(args) > 0 {
 		num := strings.TrimSpace(args[0])
@@ -378,25 +207,10 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 		return
 	}
 
-	if targetJID.User == v.Info.Sender.User && action == "remove" {
-		msg := `╔════════════════╗
-║ ❌ INVALID
-╠════════════════
-║ Cannot kick
-║ yourself
-╚════════════════`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	var actionText, actionEmoji string
 	var participantChange whatsmeow.ParticipantChange
 
 	switch action {
-	case "remove":
-		participantChange = whatsmeow.ParticipantChangeRemove
-		actionText = "Kicked"
-		actionEmoji = "👢"
 	case "promote":
 		participantChange = whatsmeow.ParticipantChangePromote
 		actionText = "Promoted"
@@ -407,7 +221,28 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 		actionEmoji = "⬇️"
 	}
 
-	client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{targetJID}, participantChange)
+	if reason := guardBlocksChange(client, v.Info.Chat, v.Info.Sender, targetJID, participantChange); reason != "" {
+		logModAction(client, v.Info.Chat, "guard", "Blocked ."+action+" ("+reason+")", v.Info.Sender, targetJID, "")
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ADMIN GUARD
+╠════════════════╣
+║ ❌ Blocked: @%s
+║ Reason: %s
+║ Ask the owner or a sudo
+╚════════════════╝`, targetJID.User, reason)
+		sendMentionText(client, v.Info.Chat, msg, []string{targetJID.String()})
+		return
+	}
+
+	res, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{targetJID}, participantChange)
+	if err == nil && len(res) > 0 && res[0].Error != 0 {
+		err = fmt.Errorf("code %d", res[0].Error)
+	}
+	if err != nil {
+		replyMessage(client, v, "❌ Failed: "+err.Error())
+		return
+	}
+	logModAction(client, v.Info.Chat, action, "Manual command", v.Info.Sender, targetJID, "")
 
 	msg := fmt.Sprintf(`╔════════════════╗
 ║ %s %s
@@ -426,4 +261,50 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 			},
 		},
 	})
-}
\ No newline at end of file
+}
+// 🎯 ٹارگٹ یوزر نکالیں: مینشن، نمبر یا ریپلائی سے (باقی آرگیومنٹس واپس)
+func resolveTarget(v *events.Message, args []string) (types.JID, []string) {
+	var ctxInfo *waProto.ContextInfo
+	if ext := v.Message.GetExtendedTextMessage(); ext != nil {
+		ctxInfo = ext.ContextInfo
+	}
+
+	if len(args) > 0 {
+		first := strings.TrimSpace(args[0])
+		// @mention: اصل JID (LID بھی ہو سکتا ہے) کانٹیکسٹ سے لیں
+		if strings.HasPrefix(first, "@") && ctxInfo != nil && len(ctxInfo.MentionedJID) > 0 {
+			if jid, err := types.ParseJID(ctxInfo.MentionedJID[0]); err == nil {
+				return jid, args[1:]
+			}
+		}
+		num := strings.TrimPrefix(strings.ReplaceAll(first, "+", ""), "@")
+		if num != "" && strings.Trim(num, "0123456789") == "" {
+			if jid, ok := parseJID(num); ok {
+				return jid, args[1:]
+			}
+		}
+	}
+
+	if ctxInfo != nil && ctxInfo.Participant != nil {
+		if jid, err := types.ParseJID(*ctxInfo.Participant); err == nil {
+			return jid, args
+		}
+	}
+	return types.EmptyJID, args
+}
+
+// 📣 مینشن کے ساتھ سادہ ٹیکسٹ بھیجیں
+func sendMentionText(client *whatsmeow.Client, chat types.JID, text string, mentions []string) {
+	client.SendMessage(context.Background(), chat, buildMentionText(text, mentions))
+}
+
+func buildMentionText(text string, mentions []string) *waProto.Message {
+	return &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waProto.ContextInfo{
+				MentionedJID: mentions,
+			},
+		},
+	}
+}
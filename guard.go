@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🛡️ ADMIN GUARD (Anti-Demote / Anti-Promote)
+// ════════════════════════════════════════════════════════════════
+// GroupSettings.AdminGuard آن ہو تو:
+//  • protected ایڈمن کو ڈیموٹ/کک کیا جائے → واپس پروموٹ/ایڈ
+//  • allow لسٹ سے باہر کسی کو پروموٹ کیا جائے → ڈیموٹ
+//  • ایسا کرنے والا ایڈمن خود ڈیموٹ، اونر کو الرٹ
+// Protected: Redis Set "protected_admins:<chatID>"
+// Allowed:   Redis Set "promote_allow:<chatID>"
+// اونر، سوڈو، بوٹ اور protected ایڈمنز کی تبدیلیاں مجاز ہیں۔
+
+func inGroupSet(client *whatsmeow.Client, key string, user types.JID) bool {
+	if rdb == nil {
+		return false
+	}
+	for _, alias := range getUserAliases(client, user) {
+		if ok, _ := rdb.SIsMember(ctx, key, alias).Result(); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isProtectedAdmin(client *whatsmeow.Client, chat, user types.JID) bool {
+	return inGroupSet(client, "protected_admins:"+chat.String(), user)
+}
+
+func isGuardAuthorized(client *whatsmeow.Client, chat, actor types.JID) bool {
+	if getCleanID(actor.User) == getCleanID(client.Store.ID.User) {
+		return true
+	}
+	return getUserRole(client, chat, actor) >= RoleSudo || isProtectedAdmin(client, chat, actor)
+}
+
+// بوٹ کی اپنی کمانڈز (.kick/.demote/.promote) پر بھی وہی اصول، ورنہ عام ایڈمن
+// بوٹ کے ذریعے گارڈ بائی پاس کر لے؛ "" = اجازت ہے
+func guardBlocksChange(client *whatsmeow.Client, chat, actor, target types.JID, change whatsmeow.ParticipantChange) string {
+	s := getGroupSettings(getCleanID(client.Store.ID.User), chat.String())
+	if !s.AdminGuard || isGuardAuthorized(client, chat, actor) {
+		return ""
+	}
+	switch change {
+	case whatsmeow.ParticipantChangeDemote, whatsmeow.ParticipantChangeRemove:
+		if isProtectedAdmin(client, chat, target) {
+			return "protected admin"
+		}
+	case whatsmeow.ParticipantChangePromote:
+		if !isProtectedAdmin(client, chat, target) && !inGroupSet(client, "promote_allow:"+chat.String(), target) {
+			return "not on promote list"
+		}
+	}
+	return ""
+}
+
+// واپسی کی کال؛ جنہیں واٹس ایپ نے رد کیا وہ (وجہ کے ساتھ) واپس
+func guardRevert(client *whatsmeow.Client, chat types.JID, users []types.JID, change whatsmeow.ParticipantChange) map[string]string {
+	failed := make(map[string]string)
+	if len(users) == 0 {
+		return failed
+	}
+	res, err := client.UpdateGroupParticipants(context.Background(), chat, users, change)
+	if err != nil {
+		fmt.Printf("⚠️ [GUARD] %s failed in %s: %v\n", change, chat.User, err)
+		for _, u := range users {
+			failed[u.User] = "request failed"
+		}
+		return failed
+	}
+
+	byUser := make(map[string]types.GroupParticipant)
+	for _, p := range res {
+		byUser[p.JID.User] = p
+		if !p.PhoneNumber.IsEmpty() {
+			byUser[p.PhoneNumber.User] = p
+		}
+	}
+	for idx, u := range users {
+		p, ok := byUser[u.User]
+		if !ok && len(res) == len(users) {
+			p, ok = res[idx], true
+		}
+		switch {
+		case !ok:
+			failed[u.User] = "no response"
+		case p.Error == 0 || p.Error == 200:
+		case change == whatsmeow.ParticipantChangeAdd:
+			failed[u.User] = fmt.Sprintf("%s, code %d", bulkStatusFromCode(change, p.Error), p.Error)
+		default:
+			failed[u.User] = fmt.Sprintf("code %d", p.Error)
+		}
+	}
+	return failed
+}
+
+// handleGroupInfoChange سے؛ true = کوئی خلاف ورزی ملی اور الٹ دی گئی
+func guardAdminChanges(client *whatsmeow.Client, s *GroupSettings, v *events.GroupInfo) bool {
+	if !s.AdminGuard || v.Sender == nil || v.Sender.IsEmpty() {
+		return false
+	}
+	if len(v.Demote) == 0 && len(v.Leave) == 0 && len(v.Promote) == 0 {
+		return false
+	}
+	chat := v.JID
+	actor := *v.Sender
+	if isGuardAuthorized(client, chat, actor) {
+		return false
+	}
+
+	var repromote, readd, undo []types.JID
+	for _, u := range v.Demote {
+		if isProtectedAdmin(client, chat, u) {
+			repromote = append(repromote, u)
+		}
+	}
+	for _, u := range v.Leave {
+		if u.User != actor.User && isProtectedAdmin(client, chat, u) {
+			readd = append(readd, u)
+		}
+	}
+	for _, u := range v.Promote {
+		if !isProtectedAdmin(client, chat, u) && !inGroupSet(client, "promote_allow:"+chat.String(), u) {
+			undo = append(undo, u)
+		}
+	}
+	if len(repromote)+len(readd)+len(undo) == 0 {
+		return false
+	}
+	demoteCount := len(repromote)
+
+	// 1. پہلے حملہ آور کو روکیں
+	actorDemoted := false
+	if _, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{actor}, whatsmeow.ParticipantChangeDemote); err == nil {
+		actorDemoted = true
+		logModAction(client, chat, "demote", "Admin guard violation", types.EmptyJID, actor, "")
+	} else {
+		fmt.Printf("⚠️ [GUARD] Could not demote %s in %s: %v\n", actor.User, chat.User, err)
+	}
+
+	// 2. نقصان واپس (جو واٹس ایپ رد کرے وہ کارڈ اور اونر کو الگ بتائیں)
+	readdFailed := guardRevert(client, chat, readd, whatsmeow.ParticipantChangeAdd)
+	for _, u := range readd {
+		if _, bad := readdFailed[u.User]; !bad {
+			repromote = append(repromote, u) // واپس آ گیا، اب ایڈمن بھی
+		}
+	}
+	promoteFailed := guardRevert(client, chat, repromote, whatsmeow.ParticipantChangePromote)
+	undoFailed := guardRevert(client, chat, undo, whatsmeow.ParticipantChangeDemote)
+	for _, u := range readd {
+		if reason, bad := promoteFailed[u.User]; bad {
+			readdFailed[u.User] = "re-added but not promoted, " + reason
+		}
+	}
+
+	var lines, failures []string
+	mentions := []string{actor.String()}
+	add := func(label, failLabel string, users []types.JID, failed map[string]string) int {
+		done := 0
+		for _, u := range users {
+			mentions = append(mentions, u.String())
+			if reason, bad := failed[u.User]; bad {
+				lines = append(lines, fmt.Sprintf("║ ❌ %s @%s (%s)", failLabel, u.User, reason))
+				failures = append(failures, fmt.Sprintf("%s %s (%s)", failLabel, u.User, reason))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("║ %s @%s", label, u.User))
+			done++
+		}
+		return done
+	}
+	repromoted := add("⬆️ Re-promoted", "Could not re-promote", repromote[:demoteCount], promoteFailed)
+	readded := add("➕ Re-added", "Could not re-add", readd, readdFailed)
+	undone := add("⬇️ Promotion undone", "Could not undo promotion of", undo, undoFailed)
+
+	status, ownerStatus := "⬇️ Demoted", "demoted"
+	if !actorDemoted {
+		status, ownerStatus = "⚠️ Could not demote", "NOT demoted"
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ADMIN GUARD
+╠════════════════╣
+║ 👤 Offender: @%s
+║ %s
+╠════════════════╣
+%s
+╚════════════════╝`, actor.User, status, strings.Join(lines, "\n"))
+	sendMentionText(client, chat, msg, mentions)
+
+	groupName := chat.User
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		groupName = info.Name
+	}
+	report := fmt.Sprintf("🛡️ *ADMIN GUARD*\n👥 Group: %s\n👤 Offender: %s (%s)\n🔁 Reverted: %d demote, %d kick, %d promote",
+		groupName, actor.User, ownerStatus, repromoted, readded, undone)
+	if len(failures) > 0 {
+		report += "\n⚠️ Failed:\n• " + strings.Join(failures, "\n• ")
+	}
+	notifyOwner(client, report)
+	return true
+}
+
+// ==================== کمانڈ ====================
+
+// .guard on|off | protect/unprotect @user | allow/disallow @user | list
+func handleGuard(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	s := getGroupSettings(botID, chat.String())
+
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	setKeys := map[string]string{
+		"protect": "protected_admins:", "unprotect": "protected_admins:",
+		"allow": "promote_allow:", "disallow": "promote_allow:",
+	}
+
+	switch sub {
+	case "on", "off":
+		s.AdminGuard = sub == "on"
+		saveGroupSettings(botID, s)
+		if s.AdminGuard {
+			replyMessage(client, v, "✅ *Admin Guard:* ON")
+		} else {
+			replyMessage(client, v, "❌ *Admin Guard:* OFF")
+		}
+
+	case "protect", "unprotect", "allow", "disallow":
+		target, _ := resolveTarget(v, args)
+		if target.User == "" {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .guard %s @user|number", sub))
+			return
+		}
+		key := setKeys[sub] + chat.String()
+		aliases := getUserAliases(client, target)
+		if sub == "protect" || sub == "allow" {
+			rdb.SAdd(ctx, key, aliases)
+		} else {
+			rdb.SRem(ctx, key, aliases)
+		}
+		labels := map[string]string{
+			"protect":   "🛡️ Protected admin",
+			"unprotect": "❌ No longer protected",
+			"allow":     "✅ May be promoted",
+			"disallow":  "❌ Removed from promote list",
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ADMIN GUARD
+╠════════════════╣
+║ 👤 User: @%s
+║ %s
+╚════════════════╝`, target.User, labels[sub])
+		sendMentionText(client, chat, msg, []string{target.String()})
+
+	case "list":
+		status := "🔴 OFF"
+		if s.AdminGuard {
+			status = "🟢 ON"
+		}
+		protected, _ := rdb.SMembers(ctx, "protected_admins:"+chat.String()).Result()
+		allowed, _ := rdb.SMembers(ctx, "promote_allow:"+chat.String()).Result()
+		sort.Strings(protected)
+		sort.Strings(allowed)
+
+		out := "╔════════════════╗\n"
+		out += "║ 🛡️ ADMIN GUARD\n"
+		out += "╠════════════════╣\n"
+		out += "║ Status: " + status + "\n"
+		out += "║ 🛡️ Protected:\n"
+		if len(protected) == 0 {
+			out += "║   (none)\n"
+		}
+		for _, p := range protected {
+			out += "║   • " + p + "\n"
+		}
+		out += "║ ✅ Promote allowed:\n"
+		if len(allowed) == 0 {
+			out += "║   (protected only)\n"
+		}
+		for _, a := range allowed {
+			out += "║   • " + a + "\n"
+		}
+		out += "╠════════════════╣\n"
+		out += "║ .guard on/off\n"
+		out += "║ .guard protect/unprotect @user\n"
+		out += "║ .guard allow/disallow @user\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .guard on|off | protect|unprotect @user | allow|disallow @user | list")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func guardTestMessage(chat, sender types.JID, text string) *events.Message {
+	return &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{Chat: chat, Sender: sender, IsGroup: true},
+			ID:            "GUARDTEST",
+			Timestamp:     time.Now(),
+		},
+		Message: &waProto.Message{Conversation: proto.String(text)},
+	}
+}
+
+func guardBlocked(chat types.JID, target types.JID) bool {
+	for _, e := range getModLog(chat.String(), 50, nil) {
+		if e.Action == "guard" && e.Target == target.User {
+			return true
+		}
+	}
+	return false
+}
+
+// .demote / .kick / .promote بوٹ کے ذریعے ہوں تو بھی گارڈ روکے
+func TestGuardBlocksBotCommands(t *testing.T) {
+	startFakeRedis(t)
+	client := offlineTestClient(t)
+	botID := getCleanID(client.Store.ID.User)
+
+	admin := types.NewJID("923220000001", types.DefaultUserServer)
+	sudo := types.NewJID("923220000002", types.DefaultUserServer)
+	protected := types.NewJID("923220000003", types.DefaultUserServer)
+	allowed := types.NewJID("923220000004", types.DefaultUserServer)
+	stranger := types.NewJID("923220000005", types.DefaultUserServer)
+	rdb.SAdd(ctx, "sudo:"+botID, sudo.User)
+
+	tests := []struct {
+		name    string
+		guard   bool
+		actor   types.JID
+		target  types.JID
+		run     func(client *whatsmeow.Client, v *events.Message, args []string)
+		blocked bool
+	}{
+		{"admin demotes protected", true, admin, protected, handleDemote, true},
+		{"admin kicks protected", true, admin, protected, handleKick, true},
+		{"admin promotes stranger", true, admin, stranger, handlePromote, true},
+		{"admin promotes allowed", true, admin, allowed, handlePromote, false},
+		{"admin demotes unprotected", true, admin, stranger, handleDemote, false},
+		{"admin kicks unprotected", true, admin, stranger, handleKick, false},
+		{"sudo demotes protected", true, sudo, protected, handleDemote, false},
+		{"sudo kicks protected", true, sudo, protected, handleKick, false},
+		{"guard off", false, admin, protected, handleDemote, false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat := types.NewJID("1203630000001"+string(rune('a'+i)), types.GroupServer)
+			s := getGroupSettings(botID, chat.String())
+			s.AdminGuard = tt.guard
+			saveGroupSettings(botID, s)
+			rdb.SAdd(ctx, "protected_admins:"+chat.String(), protected.User)
+			rdb.SAdd(ctx, "promote_allow:"+chat.String(), allowed.User)
+
+			adminMutex.Lock()
+			adminCacheMap[chat.String()] = &AdminCache{
+				Admins:    map[string]bool{admin.User: true},
+				ExpiresAt: time.Now().Add(time.Minute),
+			}
+			adminMutex.Unlock()
+
+			tt.run(client, guardTestMessage(chat, tt.actor, ""), []string{tt.target.User})
+
+			if got := guardBlocked(chat, tt.target); got != tt.blocked {
+				t.Errorf("blocked = %v, want %v", got, tt.blocked)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 💤 INACTIVE MEMBERS & PURGE
+// ════════════════════════════════════════════════════════════════
+// Activity:  Redis Hash "activity:<chatID>" (cleanID -> آخری میسج/جوائن unix)
+// Since:     Redis "activity_since:<chatID>" (ٹریکنگ کب شروع ہوئی)
+// Pending:   Redis "purge_pending:<botID>:<chatID>" (کنفرمیشن کا انتظار، 2 منٹ)
+// جس ممبر کا کوئی ریکارڈ نہیں اس کی آخری ایکٹیویٹی = ٹریکنگ شروع ہونے کا وقت،
+// تاکہ فیچر آن ہوتے ہی سب "inactive" نہ بن جائیں۔
+
+type PurgeRequest struct {
+	Requester string   `json:"requester"`
+	Days      int      `json:"days"`
+	Targets   []string `json:"targets"` // full JIDs
+}
+
+type inactiveMember struct {
+	JID      types.JID
+	LastSeen time.Time
+}
+
+const (
+	purgeConfirmTTL = 2 * time.Minute
+	purgeBatchSize  = 5
+	purgeBatchDelay = 3 * time.Second
+	inactiveListMax = 50
+)
+
+func activityKey(chatID string) string {
+	return "activity:" + chatID
+}
+
+// processMessage سے ہر گروپ میسج پر
+func recordActivity(chat, sender types.JID) {
+	if rdb == nil {
+		return
+	}
+	chatID := chat.String()
+	now := time.Now().Unix()
+	rdb.HSet(ctx, activityKey(chatID), getCleanID(sender.User), now)
+	rdb.SetNX(ctx, "activity_since:"+chatID, now, 0)
+}
+
+// نئے ممبرز کو فوراً inactive شمار نہ کیا جائے
+func recordJoins(chat types.JID, joined []types.JID) {
+	for _, u := range joined {
+		recordActivity(chat, u)
+	}
+}
+
+func findInactiveMembers(client *whatsmeow.Client, chat types.JID, days int) ([]inactiveMember, time.Time, error) {
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	chatID := chat.String()
+	activity, _ := rdb.HGetAll(ctx, activityKey(chatID)).Result()
+	since := time.Now()
+	if ts, err := rdb.Get(ctx, "activity_since:"+chatID).Int64(); err == nil {
+		since = time.Unix(ts, 0)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	botClean := getCleanID(client.Store.ID.User)
+
+	var list []inactiveMember
+	for _, p := range info.Participants {
+		// 🛡️ ایڈمنز، بوٹ، اونر/سوڈو اور ٹرسٹڈ کبھی نہیں
+		if participantIsAdmin(p) || getCleanID(p.JID.User) == botClean {
+			continue
+		}
+		if isOwner(client, p.JID) || isSudo(client, p.JID) || isTrusted(client, chat, p.JID) {
+			continue
+		}
+
+		last := since
+		for _, id := range []types.JID{p.JID, p.PhoneNumber, p.LID} {
+			if id.User == "" {
+				continue
+			}
+			if ts, err := strconv.ParseInt(activity[getCleanID(id.User)], 10, 64); err == nil {
+				if t := time.Unix(ts, 0); t.After(last) {
+					last = t
+				}
+			}
+		}
+		if last.Before(cutoff) {
+			list = append(list, inactiveMember{JID: p.JID, LastSeen: last})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].LastSeen.Before(list[j].LastSeen) })
+	return list, since, nil
+}
+
+// "30d" / "30" / "2w"
+func parseDaysArg(arg string) (int, bool) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	mult := 1
+	switch {
+	case strings.HasSuffix(arg, "w"):
+		mult, arg = 7, strings.TrimSuffix(arg, "w")
+	case strings.HasSuffix(arg, "d"):
+		arg = strings.TrimSuffix(arg, "d")
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 || n*mult > 365 {
+		return 0, false
+	}
+	return n * mult, true
+}
+
+func formatLastSeen(t, since time.Time) string {
+	if !t.After(since) {
+		return "never"
+	}
+	return fmt.Sprintf("%dd ago", int(time.Since(t).Hours()/24))
+}
+
+// ==================== کمانڈز ====================
+
+// .inactive 30d
+func handleInactive(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+
+	days := 30
+	if len(args) > 0 {
+		n, ok := parseDaysArg(args[0])
+		if !ok {
+			replyMessage(client, v, "⚠️ Usage: .inactive 30d (1-365 days)")
+			return
+		}
+		days = n
+	}
+
+	list, since, err := findInactiveMembers(client, v.Info.Chat, days)
+	if err != nil {
+		replyMessage(client, v, "❌ Could not fetch group info.")
+		return
+	}
+
+	out := "╔════════════════╗\n"
+	out += fmt.Sprintf("║ 💤 INACTIVE (%dd)\n", days)
+	out += "╠════════════════╣\n"
+	if len(list) == 0 {
+		out += "║ ✅ Everyone is active\n"
+	}
+	var mentions []string
+	for i, m := range list {
+		if i >= inactiveListMax {
+			out += fmt.Sprintf("║ ... and %d more\n", len(list)-inactiveListMax)
+			break
+		}
+		out += fmt.Sprintf("║ %d. @%s (%s)\n", i+1, m.JID.User, formatLastSeen(m.LastSeen, since))
+		mentions = append(mentions, m.JID.String())
+	}
+	out += "╠════════════════╣\n"
+	out += fmt.Sprintf("║ 📊 Total: %d\n", len(list))
+	out += "║ 📅 Tracking since: " + since.Format("02 Jan 2006") + "\n"
+	out += fmt.Sprintf("║ 🧹 .purge inactive %dd\n", days)
+	out += "╚════════════════╝"
+	sendMentionText(client, v.Info.Chat, out, mentions)
+}
+
+// .purge inactive 30d → .purge confirm | .purge cancel
+func handlePurge(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	key := "purge_pending:" + botID + ":" + chat.String()
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "inactive":
+		days := 30
+		if len(args) > 1 {
+			n, ok := parseDaysArg(args[1])
+			if !ok {
+				replyMessage(client, v, "⚠️ Usage: .purge inactive 30d")
+				return
+			}
+			days = n
+		}
+		list, _, err := findInactiveMembers(client, chat, days)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not fetch group info.")
+			return
+		}
+		if len(list) == 0 {
+			replyMessage(client, v, fmt.Sprintf("✅ No members inactive for %d days.", days))
+			return
+		}
+
+		req := PurgeRequest{Requester: getCleanID(v.Info.Sender.User), Days: days}
+		for _, m := range list {
+			req.Targets = append(req.Targets, m.JID.String())
+		}
+		jsonData, _ := json.Marshal(req)
+		rdb.Set(ctx, key, jsonData, purgeConfirmTTL)
+
+		eta := time.Duration((len(list)+purgeBatchSize-1)/purgeBatchSize) * purgeBatchDelay
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ⚠️ CONFIRM PURGE
+╠════════════════╣
+║ 💤 Inactive: %dd+
+║ 👥 Members: %d
+║ ⏱️ Est. time: %s
+║ 🛡️ Admins are never removed
+╠════════════════╣
+║ ✅ .purge confirm
+║ ❌ .purge cancel
+║ (Expires in %s)
+╚════════════════╝`, days, len(list), formatDuration(eta), formatDuration(purgeConfirmTTL))
+		replyMessage(client, v, msg)
+
+	case "confirm":
+		val, err := rdb.Get(ctx, key).Result()
+		if err != nil {
+			replyMessage(client, v, "⚠️ No pending purge. Run .purge inactive 30d first.")
+			return
+		}
+		var req PurgeRequest
+		if json.Unmarshal([]byte(val), &req) != nil {
+			rdb.Del(ctx, key)
+			return
+		}
+		if req.Requester != getCleanID(v.Info.Sender.User) {
+			replyMessage(client, v, "❌ Only the admin who started the purge can confirm it.")
+			return
+		}
+		rdb.Del(ctx, key)
+		replyMessage(client, v, fmt.Sprintf("🧹 Purging %d inactive members...", len(req.Targets)))
+		go runPurge(client, chat, v.Info.Sender, req)
+
+	case "cancel":
+		if n, _ := rdb.Del(ctx, key).Result(); n == 0 {
+			replyMessage(client, v, "⚠️ No pending purge.")
+			return
+		}
+		replyMessage(client, v, "❌ Purge cancelled.")
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .purge inactive 30d | confirm | cancel")
+	}
+}
+
+// ریٹ لمٹ کے ساتھ بیچز میں کک
+func runPurge(client *whatsmeow.Client, chat, actor types.JID, req PurgeRequest) {
+	// کنفرمیشن کے دوران کوئی ایڈمن بن گیا ہو تو دوبارہ چیک
+	admins := make(map[string]bool)
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		for _, p := range info.Participants {
+			if participantIsAdmin(p) {
+				admins[p.JID.String()] = true
+			}
+		}
+	}
+
+	var targets []types.JID
+	for _, t := range req.Targets {
+		jid, err := types.ParseJID(t)
+		if err != nil || admins[t] {
+			continue
+		}
+		targets = append(targets, jid)
+	}
+
+	removed, failed := 0, 0
+	for i := 0; i < len(targets); i += purgeBatchSize {
+		end := i + purgeBatchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batch := targets[i:end]
+
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, batch, whatsmeow.ParticipantChangeRemove)
+		if err != nil {
+			fmt.Printf("⚠️ [PURGE] Batch failed in %s: %v\n", chat.User, err)
+			failed += len(batch)
+		} else {
+			for _, p := range res {
+				if p.Error != 0 {
+					failed++
+					continue
+				}
+				removed++
+			}
+		}
+		if end < len(targets) {
+			time.Sleep(purgeBatchDelay)
+		}
+	}
+
+	logModAction(client, chat, "purge", fmt.Sprintf("Inactive %dd+ (%d removed)", req.Days, removed), actor, types.EmptyJID, "")
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🧹 PURGE COMPLETE
+╠════════════════╣
+║ ✅ Removed: %d
+║ ❌ Failed: %d
+║ 💤 Inactive: %dd+
+╚════════════════╝`, removed, failed, req.Days)
+	sendMentionText(client, chat, msg, nil)
+}
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📥 JOIN REQUESTS (Membership Approval Mode)
+// ════════════════════════════════════════════════════════════════
+// واٹس ایپ نئی ریکویسٹ کا ایونٹ نہیں بھیجتا، اس لیے پالیسی والے گروپس
+// کو وقفے وقفے سے چیک کیا جاتا ہے۔
+// Policy groups: Redis Set "join_policy_groups" ("botID|chatID")
+// DM captcha:    Redis "join_captcha:<botID>:<chatID>:<user>" (JSON, TTL)
+//                Redis Set "join_captcha_chats:<botID>:<user>" (chatIDs، DM جواب کے لیے)
+
+type JoinCaptcha struct {
+	ChatID    string    `json:"chat_id"`
+	Requester string    `json:"requester"` // full JID (LID یا نمبر)
+	Answer    string    `json:"answer"`
+	Attempts  int       `json:"attempts"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+const (
+	joinPollInterval   = 2 * time.Minute
+	joinCaptchaTimeout = 10 * time.Minute
+)
+
+func joinPolicyField(botID, chatID string) string {
+	return botID + "|" + chatID
+}
+
+func joinCaptchaKey(botID, chatID, user string) string {
+	return "join_captcha:" + botID + ":" + chatID + ":" + getCleanID(user)
+}
+
+func joinCaptchaChatsKey(botID, user string) string {
+	return "join_captcha_chats:" + botID + ":" + getCleanID(user)
+}
+
+func clearJoinCaptcha(botID, chatID, user string) {
+	rdb.Del(ctx, joinCaptchaKey(botID, chatID, user))
+	rdb.SRem(ctx, joinCaptchaChatsKey(botID, user), chatID)
+}
+
+// ریکویسٹ کرنے والے کا فون نمبر (LID ہو تو میپنگ سے)
+func phoneNumberOf(client *whatsmeow.Client, jid types.JID) string {
+	if jid.Server == types.DefaultUserServer {
+		return getCleanID(jid.User)
+	}
+	if client.Store.LIDs == nil {
+		return ""
+	}
+	pn, err := client.Store.LIDs.GetPNForLID(context.Background(), jid)
+	if err != nil || pn.IsEmpty() {
+		return ""
+	}
+	return getCleanID(pn.User)
+}
+
+// ==================== پالیسی ====================
+
+// "approve" / "reject" / "captcha" / "" (ایڈمن کے لیے چھوڑ دیں)
+func decideJoinRequest(client *whatsmeow.Client, s *GroupSettings, chatID string, req types.JID) string {
+	if findBan(client, chatID, req) != nil {
+		return "reject"
+	}
+	if len(s.JoinPrefixes) > 0 {
+		num := phoneNumberOf(client, req)
+		matched := false
+		for _, p := range s.JoinPrefixes {
+			if num != "" && strings.HasPrefix(num, p) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return ""
+		}
+	}
+	if s.RequestCaptcha {
+		return "captcha"
+	}
+	return "approve"
+}
+
+func applyJoinPolicy(client *whatsmeow.Client, chat types.JID) {
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, chat.String())
+	if !s.AutoRequests {
+		return
+	}
+
+	reqs, err := client.GetGroupRequestParticipants(context.Background(), chat)
+	if err != nil {
+		fmt.Printf("⚠️ [REQUESTS] Could not fetch requests for %s: %v\n", chat.User, err)
+		return
+	}
+
+	var approve, reject []types.JID
+	for _, r := range reqs {
+		switch decideJoinRequest(client, s, chat.String(), r.JID) {
+		case "approve":
+			approve = append(approve, r.JID)
+		case "reject":
+			reject = append(reject, r.JID)
+		case "captcha":
+			sendJoinCaptcha(client, chat, r.JID)
+		}
+	}
+
+	if len(approve) > 0 {
+		if _, err := client.UpdateGroupRequestParticipants(context.Background(), chat, approve, whatsmeow.ParticipantChangeApprove); err == nil {
+			for _, u := range approve {
+				logModAction(client, chat, "approve", "Join policy", types.EmptyJID, u, "")
+			}
+		}
+	}
+	if len(reject) > 0 {
+		if _, err := client.UpdateGroupRequestParticipants(context.Background(), chat, reject, whatsmeow.ParticipantChangeReject); err == nil {
+			for _, u := range reject {
+				logModAction(client, chat, "reject", "Join policy: banned", types.EmptyJID, u, "")
+			}
+		}
+	}
+}
+
+func startJoinRequestWatcher() {
+	ticker := time.NewTicker(joinPollInterval)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			fields, err := rdb.SMembers(ctx, "join_policy_groups").Result()
+			if err != nil {
+				continue
+			}
+			for _, field := range fields {
+				parts := strings.SplitN(field, "|", 2)
+				if len(parts) != 2 {
+					rdb.SRem(ctx, "join_policy_groups", field)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[parts[0]]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue
+				}
+
+				chat, ok := parseJID(parts[1])
+				if !ok {
+					rdb.SRem(ctx, "join_policy_groups", field)
+					continue
+				}
+				applyJoinPolicy(botClient, chat)
+			}
+		}
+	}()
+}
+
+// ==================== DM کیپچا ====================
+
+func sendJoinCaptcha(client *whatsmeow.Client, chat, user types.JID) {
+	if rdb == nil {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := chat.String()
+	key := joinCaptchaKey(botID, chatID, user.User)
+
+	// پہلے سے چیلنج بھیجا جا چکا ہو تو ایکسپائری چیک کریں
+	if val, err := rdb.Get(ctx, key).Result(); err == nil {
+		var jc JoinCaptcha
+		if json.Unmarshal([]byte(val), &jc) == nil && time.Now().After(jc.ExpiresAt) {
+			clearJoinCaptcha(botID, chatID, user.User)
+			client.UpdateGroupRequestParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangeReject)
+			logModAction(client, chat, "reject", "Join captcha timed out", types.EmptyJID, user, "")
+		}
+		return
+	}
+
+	groupName := chat.User
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		groupName = info.Name
+	}
+
+	a, b := rand.Intn(9)+1, rand.Intn(9)+1
+	jc := JoinCaptcha{
+		ChatID:    chatID,
+		Requester: user.String(),
+		Answer:    strconv.Itoa(a + b),
+		ExpiresAt: time.Now().Add(joinCaptchaTimeout),
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🧩 JOIN VERIFICATION
+╠════════════════╣
+║ 👥 Group: %s
+║ Reply with the answer:
+║ %d + %d = ?
+║ ⏱️ Time: %s
+╚════════════════╝`, groupName, a, b, formatDuration(joinCaptchaTimeout))
+
+	if _, err := client.SendMessage(context.Background(), user, buildMentionText(msg, nil)); err != nil {
+		fmt.Printf("⚠️ [REQUESTS] Could not DM captcha to %s: %v\n", user.User, err)
+		return
+	}
+	jsonData, _ := json.Marshal(jc)
+	// ٹائم آؤٹ کے بعد بھی کچھ دیر رکھیں تاکہ اگلے پول پر ریجیکٹ ہو سکے
+	rdb.Set(ctx, key, jsonData, joinCaptchaTimeout+2*joinPollInterval)
+	rdb.SAdd(ctx, joinCaptchaChatsKey(botID, user.User), chatID)
+	rdb.Expire(ctx, joinCaptchaChatsKey(botID, user.User), joinCaptchaTimeout+2*joinPollInterval)
+}
+
+// DM میں کیپچا کا جواب؛ true = میسج ہینڈل ہو گیا
+func handleJoinCaptchaReply(client *whatsmeow.Client, v *events.Message) bool {
+	if v.Info.IsGroup || v.Info.IsFromMe || rdb == nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+
+	// کئی گروپس میں ریکویسٹ ہو تو جو سب سے پہلے ایکسپائر ہو رہا ہے
+	var key, alias string
+	var jc JoinCaptcha
+	for _, a := range getUserAliases(client, v.Info.Sender) {
+		chats, _ := rdb.SMembers(ctx, joinCaptchaChatsKey(botID, a)).Result()
+		for _, chatID := range chats {
+			k := joinCaptchaKey(botID, chatID, a)
+			val, err := rdb.Get(ctx, k).Result()
+			if err != nil {
+				rdb.SRem(ctx, joinCaptchaChatsKey(botID, a), chatID)
+				continue
+			}
+			var c JoinCaptcha
+			if json.Unmarshal([]byte(val), &c) != nil || time.Now().After(c.ExpiresAt) {
+				continue
+			}
+			if key == "" || c.ExpiresAt.Before(jc.ExpiresAt) {
+				key, alias, jc = k, a, c
+			}
+		}
+	}
+	if key == "" {
+		return false
+	}
+
+	chat, ok := parseJID(jc.ChatID)
+	if !ok {
+		return false
+	}
+	requester, _ := types.ParseJID(jc.Requester)
+
+	if strings.TrimSpace(getText(v.Message)) == jc.Answer {
+		clearJoinCaptcha(botID, jc.ChatID, alias)
+		_, err := client.UpdateGroupRequestParticipants(context.Background(), chat, []types.JID{requester}, whatsmeow.ParticipantChangeApprove)
+		if err != nil {
+			replyMessage(client, v, "⚠️ Verified, but approval failed. An admin will review your request.")
+			return true
+		}
+		logModAction(client, chat, "approve", "Join captcha passed", types.EmptyJID, requester, "")
+		replyMessage(client, v, "✅ Verified! Your join request has been approved.")
+		return true
+	}
+
+	jc.Attempts++
+	if jc.Attempts >= captchaMaxAttempts {
+		clearJoinCaptcha(botID, jc.ChatID, alias)
+		client.UpdateGroupRequestParticipants(context.Background(), chat, []types.JID{requester}, whatsmeow.ParticipantChangeReject)
+		logModAction(client, chat, "reject", "Join captcha failed", types.EmptyJID, requester, "")
+		replyMessage(client, v, "❌ Verification failed. Your join request was rejected.")
+		return true
+	}
+
+	jsonData, _ := json.Marshal(jc)
+	rdb.Set(ctx, key, jsonData, time.Until(jc.ExpiresAt)+2*joinPollInterval)
+	replyMessage(client, v, fmt.Sprintf("⚠️ Wrong answer. Attempts left: %d", captchaMaxAttempts-jc.Attempts))
+	return true
+}
+
+// ==================== کمانڈ ====================
+
+func handleRequests(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	s := getGroupSettings(botID, chat.String())
+
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	val := ""
+	if len(args) > 1 {
+		val = strings.ToLower(args[1])
+	}
+
+	switch sub {
+	case "list":
+		reqs, err := client.GetGroupRequestParticipants(context.Background(), chat)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not fetch requests (Give me Admin Rights)")
+			return
+		}
+		if len(reqs) == 0 {
+			replyMessage(client, v, "📭 No pending join requests.")
+			return
+		}
+		sort.Slice(reqs, func(i, j int) bool { return reqs[i].RequestedAt.Before(reqs[j].RequestedAt) })
+
+		out := "╔════════════════╗\n"
+		out += "║ 📥 JOIN REQUESTS\n"
+		out += "╠════════════════╣\n"
+		var mentions []string
+		for i, r := range reqs {
+			out += fmt.Sprintf("║ %d. @%s\n║    🕒 %s\n", i+1, r.JID.User, r.RequestedAt.Format("02 Jan 15:04"))
+			mentions = append(mentions, r.JID.String())
+		}
+		out += fmt.Sprintf("║ 📊 Total: %d\n", len(reqs))
+		out += "╚════════════════╝"
+		sendMentionText(client, chat, out, mentions)
+
+	case "approve", "reject":
+		reqs, err := client.GetGroupRequestParticipants(context.Background(), chat)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not fetch requests (Give me Admin Rights)")
+			return
+		}
+		var targets []types.JID
+		if val == "all" {
+			for _, r := range reqs {
+				targets = append(targets, r.JID)
+			}
+		} else if target, _ := resolveTarget(v, args[1:]); target.User != "" {
+			// نمبر/مینشن کو پینڈنگ ریکویسٹ کی اصل JID (LID یا نمبر) سے ملائیں
+			aliases := make(map[string]bool)
+			for _, a := range getUserAliases(client, target) {
+				aliases[a] = true
+			}
+		match:
+			for _, r := range reqs {
+				for _, a := range getUserAliases(client, r.JID) {
+					if aliases[a] {
+						targets = []types.JID{r.JID}
+						break match
+					}
+				}
+			}
+			if len(targets) == 0 {
+				replyMessage(client, v, fmt.Sprintf("⚠️ @%s has no pending join request.", target.User))
+				return
+			}
+		}
+		if len(targets) == 0 {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .requests %s all | <number>", sub))
+			return
+		}
+
+		action := whatsmeow.ParticipantChangeApprove
+		if sub == "reject" {
+			action = whatsmeow.ParticipantChangeReject
+		}
+		res, err := client.UpdateGroupRequestParticipants(context.Background(), chat, targets, action)
+		if err != nil {
+			replyMessage(client, v, "❌ Failed: "+err.Error())
+			return
+		}
+
+		byUser := make(map[string]types.GroupParticipant)
+		for _, p := range res {
+			byUser[p.JID.User] = p
+			if !p.PhoneNumber.IsEmpty() {
+				byUser[p.PhoneNumber.User] = p
+			}
+		}
+		var failed []string
+		var mentions []string
+		for idx, u := range targets {
+			p, ok := byUser[u.User]
+			if !ok && len(res) == len(targets) {
+				p, ok = res[idx], true
+			}
+			switch {
+			case !ok:
+				failed = append(failed, fmt.Sprintf("║ ⚠️ @%s: no response", u.User))
+				mentions = append(mentions, u.String())
+			case p.Error != 0 && p.Error != 200:
+				failed = append(failed, fmt.Sprintf("║ ⚠️ @%s: code %d", u.User, p.Error))
+				mentions = append(mentions, u.String())
+			default:
+				logModAction(client, chat, sub, "Manual command", v.Info.Sender, u, "")
+			}
+		}
+		emoji := "✅"
+		if sub == "reject" {
+			emoji = "❌"
+		}
+		done := len(targets) - len(failed)
+		if len(failed) == 0 {
+			replyMessage(client, v, fmt.Sprintf("%s %d request(s) %sd.", emoji, done, sub))
+			return
+		}
+		out := "╔════════════════╗\n"
+		out += "║ 📥 JOIN REQUESTS\n"
+		out += "╠════════════════╣\n"
+		out += fmt.Sprintf("║ %s %d request(s) %sd\n", emoji, done, sub)
+		out += strings.Join(failed, "\n") + "\n"
+		out += "╚════════════════╝"
+		sendMentionText(client, chat, out, mentions)
+
+	case "auto":
+		if val != "on" && val != "off" {
+			replyMessage(client, v, "⚠️ Usage: .requests auto on | off")
+			return
+		}
+		s.AutoRequests = val == "on"
+		if rdb != nil {
+			if s.AutoRequests {
+				rdb.SAdd(ctx, "join_policy_groups", joinPolicyField(botID, chat.String()))
+			} else {
+				rdb.SRem(ctx, "join_policy_groups", joinPolicyField(botID, chat.String()))
+			}
+		}
+		saveGroupSettings(botID, s)
+		if s.AutoRequests {
+			go applyJoinPolicy(client, chat)
+			replyMessage(client, v, "✅ *Auto Join Policy:* ON")
+		} else {
+			replyMessage(client, v, "❌ *Auto Join Policy:* OFF")
+		}
+
+	case "prefix":
+		switch val {
+		case "add", "del":
+			for _, p := range args[2:] {
+				p = strings.TrimPrefix(p, "+")
+				if _, err := strconv.Atoi(p); err != nil {
+					continue
+				}
+				var list []string
+				for _, e := range s.JoinPrefixes {
+					if e != p {
+						list = append(list, e)
+					}
+				}
+				if val == "add" {
+					list = append(list, p)
+				}
+				s.JoinPrefixes = list
+			}
+		case "clear":
+			s.JoinPrefixes = nil
+		default:
+			replyMessage(client, v, "⚠️ Usage: .requests prefix add|del 92 91 | clear")
+			return
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Allowed prefixes: "+prefixSummary(s))
+
+	case "captcha":
+		if val != "on" && val != "off" {
+			replyMessage(client, v, "⚠️ Usage: .requests captcha on | off")
+			return
+		}
+		s.RequestCaptcha = val == "on"
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ DM captcha before approval: "+strings.ToUpper(val))
+
+	case "status":
+		status := "🔴 OFF"
+		if s.AutoRequests {
+			status = "🟢 ON"
+		}
+		captcha := "🔴 OFF"
+		if s.RequestCaptcha {
+			captcha = "🟢 ON"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 📥 JOIN POLICY
+╠════════════════╣
+║ Auto: %s
+║ Prefixes: %s
+║ DM Captcha: %s
+║ Banned: ❌ Always rejected
+╠════════════════╣
+║ .requests list
+║ .requests approve/reject all
+║ .requests auto on/off
+║ .requests prefix add 92
+║ .requests captcha on/off
+╚════════════════╝`, status, prefixSummary(s), captcha)
+		replyMessage(client, v, msg)
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .requests list | approve all | reject all | auto | prefix | captcha | status")
+	}
+}
+
+func prefixSummary(s *GroupSettings) string {
+	if len(s.JoinPrefixes) == 0 {
+		return "Any"
+	}
+	return "+" + strings.Join(s.JoinPrefixes, ", +")
+}
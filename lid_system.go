@@ -480,4 +480,41 @@ func isGroupAdmin(client *whatsmeow.Client, chat, user types.JID) bool {
 	}
 
 	return false
-}
\ No newline at end of file
+}
+// Get every known ID form (LID + phone) of a user, cleaned
+func getUserAliases(client *whatsmeow.Client, user types.JID) []string {
+	aliases := []string{getCleanID(user.User)}
+	if client == nil || client.Store == nil || client.Store.LIDs == nil {
+		return aliases
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var other types.JID
+	var err error
+	if user.Server == types.HiddenUserServer {
+		other, err = client.Store.LIDs.GetPNForLID(ctx, user)
+	} else {
+		other, err = client.Store.LIDs.GetLIDForPN(ctx, user.ToNonAD())
+	}
+	if err == nil && !other.IsEmpty() {
+		aliases = append(aliases, getCleanID(other.User))
+	}
+	return aliases
+}
+
+// One stable JID per person: the phone-number form when the LID is mapped,
+// so PN and LID messages of the same member land on the same record
+func canonicalUserJID(client *whatsmeow.Client, user types.JID) types.JID {
+	user = user.ToNonAD()
+	if user.Server != types.HiddenUserServer || client == nil || client.Store == nil || client.Store.LIDs == nil {
+		return user
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if pn, err := client.Store.LIDs.GetPNForLID(ctx, user); err == nil && !pn.IsEmpty() {
+		return pn.ToNonAD()
+	}
+	return user
+}
@@ -125,6 +125,17 @@ func main() {
 
 	// 5. باقی سسٹمز
 	InitLIDSystem()
+	loadMutes()
+	startMuteWatcher()
+	loadCaptchas()
+	startCaptchaWatcher()
+	startLockdownWatcher()
+	startJoinRequestWatcher()
+	startScheduleWatcher()
+	startAnnouncementWatcher()
+	startStatsWatcher()
+	startPollWatcher()
+	startReminderWatcher()
 
 	// 6. ویب سرور روٹس
 	http.HandleFunc("/", serveHTML)
@@ -135,6 +146,7 @@ func main() {
 	http.HandleFunc("/link/delete", handleDeleteSession)
 	http.HandleFunc("/del/all", handleDelAllAPI)
 	http.HandleFunc("/del/", handleDelNumberAPI)
+	http.HandleFunc("/api/modlog", handleModLogAPI)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📎 MEDIA-TYPE MODERATION
+// ════════════════════════════════════════════════════════════════
+// antipic / antivideo / antisticker کی طرح، مگر ہر ٹائپ کا اپنا ایکشن
+// (delete / deletewarn / deletekick) جو GroupSettings.MediaRules میں محفوظ ہے۔
+
+var mediaRuleTypes = []string{"document", "audio", "voice", "poll", "contact", "location", "viewonce", "forwarded"}
+
+var mediaRuleLabels = map[string]string{
+	"document":  "Document",
+	"audio":     "Audio",
+	"voice":     "Voice note",
+	"poll":      "Poll",
+	"contact":   "Contact",
+	"location":  "Location",
+	"viewonce":  "View-once media",
+	"forwarded": "Forwarded many times",
+}
+
+const defaultForwardLimit = 5 // واٹس ایپ اسی اسکور پر "Forwarded many times" دکھاتا ہے
+
+// میسج کس رول سے میچ ہوتا ہے؟ (خالی = کوئی نہیں)
+func matchMediaRule(v *events.Message, s *GroupSettings) (string, string) {
+	if len(s.MediaRules) == 0 {
+		return "", ""
+	}
+	m := v.Message
+
+	check := func(mediaType string, hit bool) bool {
+		return hit && s.MediaRules[mediaType] != ""
+	}
+
+	if check("viewonce", v.IsViewOnce || m.GetImageMessage().GetViewOnce() || m.GetVideoMessage().GetViewOnce()) {
+		return "viewonce", "View-once media not allowed"
+	}
+
+	if doc := m.GetDocumentMessage(); check("document", doc != nil) {
+		if len(s.BlockedExts) == 0 {
+			return "document", "Document not allowed"
+		}
+		if ext, blocked := isBlockedDocument(doc, s.BlockedExts); blocked {
+			return "document", "Blocked file type: " + ext
+		}
+	}
+
+	if audio := m.GetAudioMessage(); audio != nil {
+		if check("voice", audio.GetPTT()) {
+			return "voice", "Voice note not allowed"
+		}
+		if check("audio", !audio.GetPTT()) {
+			return "audio", "Audio not allowed"
+		}
+	}
+
+	isPoll := m.PollCreationMessage != nil || m.PollCreationMessageV2 != nil ||
+		m.PollCreationMessageV3 != nil || m.PollCreationMessageV4 != nil || m.PollCreationMessageV5 != nil
+	if check("poll", isPoll) {
+		return "poll", "Poll not allowed"
+	}
+
+	if check("contact", m.ContactMessage != nil || m.ContactsArrayMessage != nil) {
+		return "contact", "Contact not allowed"
+	}
+
+	if check("location", m.LocationMessage != nil || m.LiveLocationMessage != nil) {
+		return "location", "Location not allowed"
+	}
+
+	if s.MediaRules["forwarded"] != "" {
+		limit := s.ForwardLimit
+		if limit <= 0 {
+			limit = defaultForwardLimit
+		}
+		if ci := messageContextInfo(m); ci != nil && int(ci.GetForwardingScore()) >= limit {
+			return "forwarded", "Forwarded many times"
+		}
+	}
+
+	return "", ""
+}
+
+// ایکسٹینشن (apk) یا MIME (application/vnd.android) دونوں سے میچ کریں
+func isBlockedDocument(doc *waProto.DocumentMessage, blocked []string) (string, bool) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(doc.GetFileName())), ".")
+	mime := strings.ToLower(doc.GetMimetype())
+	for _, b := range blocked {
+		if strings.Contains(b, "/") {
+			if strings.HasPrefix(mime, b) {
+				return b, true
+			}
+		} else if ext != "" && ext == b {
+			return "." + b, true
+		}
+	}
+	return "", false
+}
+
+func messageContextInfo(m *waProto.Message) *waProto.ContextInfo {
+	switch {
+	case m.ExtendedTextMessage != nil:
+		return m.ExtendedTextMessage.ContextInfo
+	case m.ImageMessage != nil:
+		return m.ImageMessage.ContextInfo
+	case m.VideoMessage != nil:
+		return m.VideoMessage.ContextInfo
+	case m.AudioMessage != nil:
+		return m.AudioMessage.ContextInfo
+	case m.DocumentMessage != nil:
+		return m.DocumentMessage.ContextInfo
+	case m.StickerMessage != nil:
+		return m.StickerMessage.ContextInfo
+	case m.LocationMessage != nil:
+		return m.LocationMessage.ContextInfo
+	case m.ContactMessage != nil:
+		return m.ContactMessage.ContextInfo
+	}
+	return nil
+}
+
+func mediaActionLabel(action string) string {
+	switch action {
+	case "deletewarn":
+		return "Delete + Warn"
+	case "deletekick":
+		return "Delete + Kick"
+	case "delete":
+		return "Delete Only"
+	}
+	return "🔴 OFF"
+}
+
+// ==================== کمانڈ ====================
+
+func handleAntiMedia(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	if s.MediaRules == nil {
+		s.MediaRules = make(map[string]string)
+	}
+
+	if len(args) == 0 {
+		out := "╔════════════════╗\n"
+		out += "║ 📎 MEDIA RULES\n"
+		out += "╠════════════════╣\n"
+		for _, t := range mediaRuleTypes {
+			out += fmt.Sprintf("║ %s: %s\n", mediaRuleLabels[t], mediaActionLabel(s.MediaRules[t]))
+		}
+		exts := "All documents"
+		if len(s.BlockedExts) > 0 {
+			exts = strings.Join(s.BlockedExts, ", ")
+		}
+		limit := s.ForwardLimit
+		if limit <= 0 {
+			limit = defaultForwardLimit
+		}
+		out += "╠════════════════╣\n"
+		out += "║ 📄 Doc filter: " + exts + "\n"
+		out += fmt.Sprintf("║ 🔁 Forward limit: %d\n", limit)
+		out += "╠════════════════╣\n"
+		out += "║ .antimedia setup\n"
+		out += "║ .antimedia <type> delete/warn/kick/off\n"
+		out += "║ .antimedia ext add apk exe\n"
+		out += "║ .antimedia ext del apk | clear\n"
+		out += "║ .antimedia fwdlimit 5\n"
+		out += "║ Types: " + strings.Join(mediaRuleTypes, ", ") + "\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+		return
+	}
+
+	opt := strings.ToLower(args[0])
+
+	switch opt {
+	case "setup":
+		startWizard(client, v, "antimedia", botID, v.Info.Chat.String())
+		return
+
+	case "ext":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .antimedia ext add|del <ext/mime...> | clear")
+			return
+		}
+		sub := strings.ToLower(args[1])
+		if sub == "clear" {
+			s.BlockedExts = nil
+			saveGroupSettings(botID, s)
+			replyMessage(client, v, "✅ Document filter cleared (all documents match).")
+			return
+		}
+		if (sub != "add" && sub != "del") || len(args) < 3 {
+			replyMessage(client, v, "⚠️ Usage: .antimedia ext add|del <ext/mime...> | clear")
+			return
+		}
+		set := make(map[string]bool)
+		for _, e := range s.BlockedExts {
+			set[e] = true
+		}
+		for _, e := range args[2:] {
+			e = strings.TrimPrefix(strings.ToLower(e), ".")
+			if sub == "add" {
+				set[e] = true
+			} else {
+				delete(set, e)
+			}
+		}
+		s.BlockedExts = nil
+		for e := range set {
+			s.BlockedExts = append(s.BlockedExts, e)
+		}
+		sort.Strings(s.BlockedExts)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Blocked file types: "+strings.Join(s.BlockedExts, ", "))
+		return
+
+	case "fwdlimit":
+		n := 0
+		if len(args) > 1 {
+			n, _ = strconv.Atoi(args[1])
+		}
+		if n < 1 || n > 127 {
+			replyMessage(client, v, "⚠️ Usage: .antimedia fwdlimit <1-127>")
+			return
+		}
+		s.ForwardLimit = n
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ Forward limit set to %d", n))
+		return
+	}
+
+	if _, ok := mediaRuleLabels[opt]; !ok {
+		replyMessage(client, v, "⚠️ Unknown type. Types: "+strings.Join(mediaRuleTypes, ", "))
+		return
+	}
+
+	action := ""
+	if len(args) > 1 {
+		action = strings.ToLower(args[1])
+	}
+	switch action {
+	case "delete", "on":
+		s.MediaRules[opt] = "delete"
+	case "warn":
+		s.MediaRules[opt] = "deletewarn"
+	case "kick":
+		s.MediaRules[opt] = "deletekick"
+	case "off":
+		delete(s.MediaRules, opt)
+	default:
+		replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .antimedia %s delete | warn | kick | off", opt))
+		return
+	}
+
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, fmt.Sprintf("✅ *%s:* %s", mediaRuleLabels[opt], mediaActionLabel(s.MediaRules[opt])))
+}
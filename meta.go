@@ -0,0 +1,527 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🗂️ GROUP METADATA SNAPSHOT / LOCK / RESTORE
+// ════════════════════════════════════════════════════════════════
+// Snapshots: Redis List "group_snapshots:<chatID>" (تازہ ترین پہلے، زیادہ سے زیادہ 5)
+// Icons:     Redis "group_icon:<chatID>:<takenAt>" (JPEG bytes)
+// Locks:     Redis Hash "meta_lock:<chatID>" (name/desc/icon -> محفوظ ویلیو)
+//            آئیکن کی تصویر "meta_lock_icon:<chatID>" میں
+// لاک شدہ چیز کو اونر/سوڈو/بوٹ کے علاوہ کوئی بدلے تو فوراً واپس کر دی جاتی ہے۔
+
+type GroupSnapshot struct {
+	Name     string    `json:"name"`
+	Desc     string    `json:"desc"`
+	IconID   string    `json:"icon_id"`
+	Announce bool      `json:"announce"`
+	Locked   bool      `json:"locked"`
+	Admins   []string  `json:"admins"`
+	TakenAt  time.Time `json:"taken_at"`
+	TakenBy  string    `json:"taken_by"`
+}
+
+const maxGroupSnapshots = 5
+
+var metaLockFields = []string{"name", "desc", "icon"}
+
+func groupIconKey(chatID string, t time.Time) string {
+	return fmt.Sprintf("group_icon:%s:%d", chatID, t.Unix())
+}
+
+// موجودہ آئیکن (ID + تصویر)؛ آئیکن نہ ہو تو خالی
+func fetchGroupIcon(client *whatsmeow.Client, chat types.JID) (string, []byte) {
+	pic, err := client.GetProfilePictureInfo(context.Background(), chat, &whatsmeow.GetProfilePictureParams{})
+	if err != nil || pic == nil {
+		return "", nil
+	}
+	return pic.ID, fetchProfilePicture(client, chat)
+}
+
+func takeGroupSnapshot(client *whatsmeow.Client, chat types.JID, by string) (*GroupSnapshot, error) {
+	if rdb == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &GroupSnapshot{
+		Name:     info.Name,
+		Desc:     info.Topic,
+		Announce: info.IsAnnounce,
+		Locked:   info.IsLocked,
+		TakenAt:  time.Now(),
+		TakenBy:  by,
+	}
+	for _, p := range info.Participants {
+		if participantIsAdmin(p) {
+			snap.Admins = append(snap.Admins, p.JID.String())
+		}
+	}
+
+	chatID := chat.String()
+	iconID, icon := fetchGroupIcon(client, chat)
+	snap.IconID = iconID
+	if len(icon) > 0 {
+		rdb.Set(ctx, groupIconKey(chatID, snap.TakenAt), icon, 0)
+	}
+
+	jsonData, _ := json.Marshal(snap)
+	key := "group_snapshots:" + chatID
+	rdb.LPush(ctx, key, jsonData)
+
+	// پرانے اسنیپ شاٹس کی تصاویر بھی صاف کریں
+	old, _ := rdb.LRange(ctx, key, maxGroupSnapshots, -1).Result()
+	for _, o := range old {
+		var s GroupSnapshot
+		if json.Unmarshal([]byte(o), &s) == nil {
+			rdb.Del(ctx, groupIconKey(chatID, s.TakenAt))
+		}
+	}
+	rdb.LTrim(ctx, key, 0, maxGroupSnapshots-1)
+	return snap, nil
+}
+
+func getGroupSnapshots(chatID string) []GroupSnapshot {
+	var list []GroupSnapshot
+	if rdb == nil {
+		return list
+	}
+	vals, _ := rdb.LRange(ctx, "group_snapshots:"+chatID, 0, -1).Result()
+	for _, val := range vals {
+		var s GroupSnapshot
+		if json.Unmarshal([]byte(val), &s) == nil {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// ==================== لاک ====================
+
+func isMetaChangeAuthorized(client *whatsmeow.Client, chat types.JID, sender *types.JID) bool {
+	if sender == nil || sender.IsEmpty() {
+		return true // سسٹم تبدیلی
+	}
+	return getUserRole(client, chat, *sender) >= RoleSudo
+}
+
+// handleGroupInfoChange سے: نام/تفصیل کی غیر مجاز تبدیلی واپس
+func enforceMetaLocks(client *whatsmeow.Client, v *events.GroupInfo) {
+	if rdb == nil || (v.Name == nil && v.Topic == nil) {
+		return
+	}
+	chatID := v.JID.String()
+	locks, err := rdb.HGetAll(ctx, "meta_lock:"+chatID).Result()
+	if err != nil || len(locks) == 0 {
+		return
+	}
+
+	authorized := isMetaChangeAuthorized(client, v.JID, v.Sender)
+	actor := types.EmptyJID
+	if v.Sender != nil {
+		actor = *v.Sender
+	}
+
+	if baseline, locked := locks["name"]; locked && v.Name != nil && v.Name.Name != baseline {
+		if authorized {
+			rdb.HSet(ctx, "meta_lock:"+chatID, "name", v.Name.Name)
+		} else if err := client.SetGroupName(context.Background(), v.JID, baseline); err == nil {
+			logModAction(client, v.JID, "revert", "Locked name changed", types.EmptyJID, actor, v.Name.Name)
+			sendMetaRevertNotice(client, v.JID, actor, "name")
+		}
+	}
+
+	if baseline, locked := locks["desc"]; locked && v.Topic != nil && v.Topic.Topic != baseline {
+		if authorized {
+			rdb.HSet(ctx, "meta_lock:"+chatID, "desc", v.Topic.Topic)
+		} else if err := client.SetGroupDescription(context.Background(), v.JID, baseline); err == nil {
+			logModAction(client, v.JID, "revert", "Locked description changed", types.EmptyJID, actor, v.Topic.Topic)
+			sendMetaRevertNotice(client, v.JID, actor, "description")
+		}
+	}
+}
+
+// *events.Picture (صرف گروپس)
+func handleGroupPictureChange(client *whatsmeow.Client, v *events.Picture) {
+	if rdb == nil || v.JID.Server != types.GroupServer {
+		return
+	}
+	chatID := v.JID.String()
+	baseline, err := rdb.HGet(ctx, "meta_lock:"+chatID, "icon").Result()
+	if err != nil || (!v.Remove && v.PictureID == baseline) {
+		return
+	}
+
+	author := v.Author
+	if isMetaChangeAuthorized(client, v.JID, &author) {
+		_, icon := fetchGroupIcon(client, v.JID)
+		rdb.HSet(ctx, "meta_lock:"+chatID, "icon", v.PictureID)
+		if len(icon) > 0 {
+			rdb.Set(ctx, "meta_lock_icon:"+chatID, icon, 0)
+		} else {
+			rdb.Del(ctx, "meta_lock_icon:"+chatID)
+		}
+		return
+	}
+
+	// پہلے آئیکن نہیں تھا → ہٹا دیں؛ تھا مگر محفوظ نہ ہو سکا → چھیڑیں نہیں
+	icon, _ := rdb.Get(ctx, "meta_lock_icon:"+chatID).Bytes()
+	if len(icon) == 0 {
+		if baseline != "" {
+			fmt.Printf("⚠️ [LOCK] Icon not captured for %s, leaving it as is\n", v.JID.User)
+			sendMentionText(client, v.JID, `╔════════════════╗
+║ 🔐 LOCKED
+╠════════════════╣
+║ Group icon is locked
+║ ⚠️ Icon not captured, left unchanged
+╚════════════════╝`, nil)
+			return
+		}
+		icon = nil
+	}
+	newID, err := client.SetGroupPhoto(context.Background(), v.JID, icon)
+	if err != nil {
+		fmt.Printf("⚠️ [LOCK] Icon revert failed for %s: %v\n", v.JID.User, err)
+		return
+	}
+	rdb.HSet(ctx, "meta_lock:"+chatID, "icon", newID)
+	logModAction(client, v.JID, "revert", "Locked icon changed", types.EmptyJID, author, "")
+	sendMetaRevertNotice(client, v.JID, author, "icon")
+}
+
+func sendMetaRevertNotice(client *whatsmeow.Client, chat, actor types.JID, what string) {
+	var mentions []string
+	by := "Unknown"
+	if !actor.IsEmpty() {
+		by = "@" + actor.User
+		mentions = append(mentions, actor.String())
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🔐 LOCKED
+╠════════════════╣
+║ Group %s is locked
+║ 👤 Changed by: %s
+║ ↩️ Reverted
+╚════════════════╝`, what, by)
+	sendMentionText(client, chat, msg, mentions)
+}
+
+// ==================== کمانڈز ====================
+
+// .snapshot | .snapshot list
+func handleSnapshot(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	chatID := v.Info.Chat.String()
+
+	if len(args) > 0 && strings.ToLower(args[0]) == "list" {
+		list := getGroupSnapshots(chatID)
+		if len(list) == 0 {
+			replyMessage(client, v, "📭 No snapshots. Use .snapshot to save one.")
+			return
+		}
+		out := "╔════════════════╗\n"
+		out += "║ 🗂️ SNAPSHOTS\n"
+		out += "╠════════════════╣\n"
+		for i, s := range list {
+			out += fmt.Sprintf("║ %d. %s — %s\n║    👮 %d admins\n", i+1, s.TakenAt.Format("02 Jan 15:04"), truncateText(s.Name, 25), len(s.Admins))
+		}
+		out += "╠════════════════╣\n"
+		out += "║ .restore <n> [name|desc|icon|settings|admins|all]\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+		return
+	}
+
+	snap, err := takeGroupSnapshot(client, v.Info.Chat, v.Info.Sender.User)
+	if err != nil {
+		replyMessage(client, v, "❌ Snapshot failed: "+err.Error())
+		return
+	}
+	icon := "❌ None"
+	if snap.IconID != "" {
+		icon = "✅ Saved"
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🗂️ SNAPSHOT SAVED
+╠════════════════╣
+║ 📛 Name: %s
+║ 📝 Desc: %d chars
+║ 🖼️ Icon: %s
+║ 📢 Admin-only chat: %v
+║ 🔒 Admin-only edit: %v
+║ 👮 Admins: %d
+╚════════════════╝`, snap.Name, len([]rune(snap.Desc)), icon, snap.Announce, snap.Locked, len(snap.Admins))
+	replyMessage(client, v, msg)
+}
+
+// .lock name|desc|icon|all | .lock (لسٹ)
+// .unlock name|desc|icon|all
+func handleMetaLock(client *whatsmeow.Client, v *events.Message, args []string, lock bool) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	chat := v.Info.Chat
+	chatID := chat.String()
+	key := "meta_lock:" + chatID
+
+	if len(args) == 0 {
+		locks, _ := rdb.HGetAll(ctx, key).Result()
+		out := "╔════════════════╗\n"
+		out += "║ 🔐 METADATA LOCKS\n"
+		out += "╠════════════════╣\n"
+		for _, f := range metaLockFields {
+			status := "🔓 Unlocked"
+			if _, ok := locks[f]; ok {
+				status = "🔐 Locked"
+			}
+			out += fmt.Sprintf("║ %s: %s\n", strings.ToUpper(f), status)
+		}
+		out += "╠════════════════╣\n"
+		out += "║ .lock name|desc|icon|all\n"
+		out += "║ .unlock name|desc|icon|all\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+		return
+	}
+
+	var fields []string
+	switch f := strings.ToLower(args[0]); f {
+	case "all":
+		fields = metaLockFields
+	case "name", "desc", "icon":
+		fields = []string{f}
+	case "description":
+		fields = []string{"desc"}
+	default:
+		replyMessage(client, v, "⚠️ Usage: .lock name | desc | icon | all")
+		return
+	}
+
+	if !lock {
+		rdb.HDel(ctx, key, fields...)
+		for _, f := range fields {
+			if f == "icon" {
+				rdb.Del(ctx, "meta_lock_icon:"+chatID)
+			}
+		}
+		replyMessage(client, v, "🔓 Unlocked: "+strings.Join(fields, ", "))
+		return
+	}
+
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Could not fetch group info.")
+		return
+	}
+	for _, f := range fields {
+		switch f {
+		case "name":
+			rdb.HSet(ctx, key, "name", info.Name)
+		case "desc":
+			rdb.HSet(ctx, key, "desc", info.Topic)
+		case "icon":
+			iconID, icon := fetchGroupIcon(client, chat)
+			rdb.HSet(ctx, key, "icon", iconID)
+			if len(icon) > 0 {
+				rdb.Set(ctx, "meta_lock_icon:"+chatID, icon, 0)
+			}
+		}
+	}
+	// لاک کے ساتھ بیک اپ بھی
+	takeGroupSnapshot(client, chat, v.Info.Sender.User)
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🔐 LOCKED
+╠════════════════╣
+║ %s
+║ Changes by admins will be
+║ reverted (Owner/Sudo allowed)
+║ 🗂️ Snapshot saved
+╚════════════════╝`, strings.ToUpper(strings.Join(fields, ", ")))
+	replyMessage(client, v, msg)
+}
+
+// .restore [n] [name|desc|icon|settings|admins|all]
+func handleRestore(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	chat := v.Info.Chat
+	chatID := chat.String()
+
+	list := getGroupSnapshots(chatID)
+	if len(list) == 0 {
+		replyMessage(client, v, "📭 No snapshots. Use .snapshot first.")
+		return
+	}
+
+	idx := 1
+	if len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			idx = n
+			args = args[1:]
+		}
+	}
+	if idx < 1 || idx > len(list) {
+		replyMessage(client, v, fmt.Sprintf("⚠️ Snapshot must be 1-%d (see .snapshot list)", len(list)))
+		return
+	}
+	snap := list[idx-1]
+
+	parts := map[string]bool{"name": true, "desc": true, "icon": true, "settings": true}
+	if len(args) > 0 {
+		parts = make(map[string]bool)
+		for _, a := range args {
+			a = strings.ToLower(a)
+			if a == "all" {
+				for _, p := range []string{"name", "desc", "icon", "settings", "admins"} {
+					parts[p] = true
+				}
+				continue
+			}
+			parts[a] = true
+		}
+	}
+
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Could not fetch group info.")
+		return
+	}
+
+	var done []string
+	fail := func(what string, err error) {
+		done = append(done, fmt.Sprintf("❌ %s: %v", what, err))
+	}
+	ok := func(what string) {
+		done = append(done, "✅ "+what)
+	}
+
+	if parts["name"] && info.Name != snap.Name {
+		if err := client.SetGroupName(context.Background(), chat, snap.Name); err != nil {
+			fail("Name", err)
+		} else {
+			ok("Name")
+		}
+	}
+	if parts["desc"] && info.Topic != snap.Desc {
+		if err := client.SetGroupDescription(context.Background(), chat, snap.Desc); err != nil {
+			fail("Description", err)
+		} else {
+			ok("Description")
+		}
+	}
+	if parts["icon"] {
+		currentID, _ := fetchGroupIcon(client, chat)
+		if currentID != snap.IconID {
+			icon, _ := rdb.Get(ctx, groupIconKey(chatID, snap.TakenAt)).Bytes()
+			if len(icon) == 0 {
+				icon = nil // سنیپ شاٹ میں آئیکن نہیں تھا → ہٹا دیں
+			}
+			if icon == nil && snap.IconID != "" {
+				done = append(done, "⚠️ Icon: not captured, left unchanged")
+			} else if _, err := client.SetGroupPhoto(context.Background(), chat, icon); err != nil {
+				fail("Icon", err)
+			} else {
+				ok("Icon")
+			}
+		}
+	}
+	if parts["settings"] {
+		if info.IsAnnounce != snap.Announce {
+			if err := client.SetGroupAnnounce(context.Background(), chat, snap.Announce); err != nil {
+				fail("Admin-only chat", err)
+			} else {
+				ok("Admin-only chat")
+			}
+		}
+		if info.IsLocked != snap.Locked {
+			if err := client.SetGroupLocked(context.Background(), chat, snap.Locked); err != nil {
+				fail("Admin-only edit", err)
+			} else {
+				ok("Admin-only edit")
+			}
+		}
+	}
+	if parts["admins"] {
+		promoted, demoted := restoreAdmins(client, chat, info, snap)
+		if promoted+demoted > 0 {
+			ok(fmt.Sprintf("Admins (+%d / -%d)", promoted, demoted))
+		}
+	}
+
+	logModAction(client, chat, "restore", fmt.Sprintf("Snapshot %s", snap.TakenAt.Format("02 Jan 15:04")), v.Info.Sender, types.EmptyJID, "")
+
+	if len(done) == 0 {
+		done = []string{"✅ Already matches snapshot"}
+	}
+	out := "╔════════════════╗\n"
+	out += "║ ♻️ RESTORED\n"
+	out += "╠════════════════╣\n"
+	out += "║ 🗂️ " + snap.TakenAt.Format("02 Jan 2006 15:04") + "\n"
+	for _, d := range done {
+		out += "║ " + d + "\n"
+	}
+	out += "╚════════════════╝"
+	replyMessage(client, v, out)
+}
+
+// اسنیپ شاٹ والے ایڈمنز واپس، نئے ایڈمنز ہٹائیں (بوٹ، سپر ایڈمن، اونر/سوڈو نہیں)
+func restoreAdmins(client *whatsmeow.Client, chat types.JID, info *types.GroupInfo, snap GroupSnapshot) (int, int) {
+	wanted := make(map[string]bool)
+	for _, a := range snap.Admins {
+		wanted[a] = true
+	}
+	botClean := getCleanID(client.Store.ID.User)
+
+	var promote, demote []types.JID
+	for _, p := range info.Participants {
+		isAdminNow := participantIsAdmin(p)
+		switch {
+		case wanted[p.JID.String()] && !isAdminNow:
+			promote = append(promote, p.JID)
+		case !wanted[p.JID.String()] && isAdminNow && !p.IsSuperAdmin:
+			if getCleanID(p.JID.User) == botClean || getUserRole(client, chat, p.JID) >= RoleSudo {
+				continue
+			}
+			demote = append(demote, p.JID)
+		}
+	}
+
+	promoted, demoted := 0, 0
+	if len(promote) > 0 {
+		if _, err := client.UpdateGroupParticipants(context.Background(), chat, promote, whatsmeow.ParticipantChangePromote); err == nil {
+			promoted = len(promote)
+		}
+	}
+	if len(demote) > 0 {
+		if _, err := client.UpdateGroupParticipants(context.Background(), chat, demote, whatsmeow.ParticipantChangeDemote); err == nil {
+			demoted = len(demote)
+		}
+	}
+	return promoted, demoted
+}
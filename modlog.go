@@ -0,0 +1,249 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📜 MODERATION AUDIT LOG
+// ════════════════════════════════════════════════════════════════
+// Every moderation action is pushed to the Redis list "modlog:<chatID>"
+// (newest first, capped at modLogMaxEntries) and optionally forwarded
+// to a designated log group.
+
+type ModLogEntry struct {
+	Time    time.Time `json:"time"`
+	BotID   string    `json:"bot_id"`
+	ChatID  string    `json:"chat_id"`
+	Action  string    `json:"action"`
+	Rule    string    `json:"rule"`
+	Actor   string    `json:"actor"`
+	Target  string    `json:"target"`
+	Excerpt string    `json:"excerpt,omitempty"`
+}
+
+const modLogMaxEntries = 500
+
+// actor خالی ہو تو ایکشن بوٹ نے خود لیا
+func logModAction(client *whatsmeow.Client, chat types.JID, action, rule string, actor, target types.JID, excerpt string) {
+	botID := getCleanID(client.Store.ID.User)
+	e := ModLogEntry{
+		Time:    time.Now(),
+		BotID:   botID,
+		ChatID:  chat.String(),
+		Action:  action,
+		Rule:    rule,
+		Actor:   "bot",
+		Target:  target.User,
+		Excerpt: truncateText(excerpt, 100),
+	}
+	if !actor.IsEmpty() {
+		e.Actor = actor.User
+	}
+
+	fmt.Printf("📜 [MODLOG] %s | %s | %s → %s | %s\n", chat.User, action, e.Actor, e.Target, rule)
+
+	if rdb != nil {
+		if jsonData, err := json.Marshal(e); err == nil {
+			key := "modlog:" + e.ChatID
+			rdb.LPush(ctx, key, jsonData)
+			rdb.LTrim(ctx, key, 0, modLogMaxEntries-1)
+		}
+	}
+
+	// 📤 لاگ گروپ میں فارورڈ
+	s := getGroupSettings(botID, e.ChatID)
+	if s.ModLogGroup == "" || s.ModLogGroup == e.ChatID {
+		return
+	}
+	logChat, ok := parseJID(s.ModLogGroup)
+	if !ok {
+		return
+	}
+	sendMentionText(client, logChat, formatModLogEntry(e, true), nil)
+}
+
+func formatModLogEntry(e ModLogEntry, withGroup bool) string {
+	out := fmt.Sprintf("🕒 %s | *%s*\n", e.Time.Format("02 Jan 15:04"), strings.ToUpper(e.Action))
+	if withGroup {
+		out += "👥 Group: " + e.ChatID + "\n"
+	}
+	out += fmt.Sprintf("👮 By: %s → 👤 %s\n📝 Rule: %s", e.Actor, e.Target, e.Rule)
+	if e.Excerpt != "" {
+		out += "\n💬 \"" + e.Excerpt + "\""
+	}
+	return out
+}
+
+// users = ایک ہی شخص کے PN/LID aliases؛ خالی ہو تو سب
+func getModLog(chatID string, limit int, users []string) []ModLogEntry {
+	if rdb == nil {
+		return nil
+	}
+	vals, err := rdb.LRange(ctx, "modlog:"+chatID, 0, modLogMaxEntries-1).Result()
+	if err != nil {
+		return nil
+	}
+	match := make(map[string]bool)
+	for _, u := range users {
+		match[getCleanID(u)] = true
+	}
+	var list []ModLogEntry
+	for _, val := range vals {
+		var e ModLogEntry
+		if json.Unmarshal([]byte(val), &e) != nil {
+			continue
+		}
+		if len(match) > 0 && !match[getCleanID(e.Target)] && !match[getCleanID(e.Actor)] {
+			continue
+		}
+		list = append(list, e)
+		if limit > 0 && len(list) >= limit {
+			break
+		}
+	}
+	return list
+}
+
+func truncateText(s string, n int) string {
+	r := []rune(strings.TrimSpace(s))
+	if len(r) <= n {
+		return string(r)
+	}
+	return string(r[:n]) + "…"
+}
+
+// ==================== کمانڈ ====================
+
+func handleModLog(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+
+	// .modlog forward <groupID|here|off>
+	if len(args) > 0 && strings.ToLower(args[0]) == "forward" {
+		s := getGroupSettings(botID, chatID)
+		if len(args) < 2 {
+			target := s.ModLogGroup
+			if target == "" {
+				target = "OFF"
+			}
+			replyMessage(client, v, "📤 *Log Forwarding:* "+target+"\n⚠️ Usage: .modlog forward <group id> | off")
+			return
+		}
+		switch strings.ToLower(args[1]) {
+		case "off":
+			s.ModLogGroup = ""
+			replyMessage(client, v, "❌ *Log Forwarding:* OFF")
+		default:
+			groupID := args[1]
+			if !strings.Contains(groupID, "@") {
+				groupID += "@g.us"
+			}
+			jid, ok := parseJID(groupID)
+			if !ok || jid.Server != types.GroupServer {
+				replyMessage(client, v, "❌ Invalid group ID. Use .id inside the log group.")
+				return
+			}
+			s.ModLogGroup = jid.String()
+			replyMessage(client, v, "✅ *Log Forwarding:* "+s.ModLogGroup)
+		}
+		saveGroupSettings(botID, s)
+		return
+	}
+
+	// .modlog [@user] [n]
+	limit := 10
+	var rest []string
+	for _, a := range args {
+		if n, err := strconv.Atoi(a); err == nil && n > 0 && len(a) <= 3 {
+			limit = n
+			continue
+		}
+		rest = append(rest, a)
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	var users []string
+	if target, _ := resolveTarget(v, rest); target.User != "" {
+		users = getUserAliases(client, target)
+	}
+
+	list := getModLog(chatID, limit, users)
+	if len(list) == 0 {
+		replyMessage(client, v, "📭 No moderation actions recorded.")
+		return
+	}
+
+	out := "╔════════════════╗\n"
+	out += "║ 📜 MODERATION LOG\n"
+	out += "╠════════════════╣\n"
+	for _, e := range list {
+		out += formatModLogEntry(e, false) + "\n┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈\n"
+	}
+	out += fmt.Sprintf("📊 Showing: %d", len(list))
+
+	replyMessage(client, v, out)
+}
+
+// ==================== ایڈمن API ====================
+
+// GET /api/modlog?group=<jid>&limit=100[&user=923xx]&token=<ADMIN_TOKEN>
+// ADMIN_TOKEN سیٹ نہ ہو تو API بند (403)، کیونکہ لاگ میں میسج کے اقتباسات ہیں
+func handleModLogAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token := getEnv("ADMIN_TOKEN", "")
+	if token == "" {
+		http.Error(w, `{"error":"Mod log API disabled (ADMIN_TOKEN not set)"}`, http.StatusForbidden)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) != 1 {
+		http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	group := r.URL.Query().Get("group")
+	if group == "" {
+		http.Error(w, `{"error":"group required"}`, 400)
+		return
+	}
+	if !strings.Contains(group, "@") {
+		group += "@g.us"
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > modLogMaxEntries {
+		limit = 100
+	}
+
+	var users []string
+	if user := r.URL.Query().Get("user"); user != "" {
+		users = []string{user}
+	}
+	list := getModLog(group, limit, users)
+	if list == nil {
+		list = []ModLogEntry{}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"group":   group,
+		"count":   len(list),
+		"entries": list,
+	})
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🔇 TIMED MUTE SYSTEM
+// ════════════════════════════════════════════════════════════════
+// WhatsApp has no per-user mute, so a muted member simply gets every
+// message revoked by the bot until the timer runs out.
+
+type MuteEntry struct {
+	BotID     string    `json:"bot_id"`
+	ChatID    string    `json:"chat_id"`
+	User      string    `json:"user"`              // full JID for mentions
+	Aliases   []string  `json:"aliases,omitempty"` // PN + LID (getUserAliases)
+	Reason    string    `json:"reason"`
+	By        string    `json:"by"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+var (
+	muteMap   = make(map[string]*MuteEntry) // botID:chatID:alias -> entry (ہر alias کے لیے)
+	muteMutex sync.RWMutex
+)
+
+func muteKey(botID, chatID, user string) string {
+	return botID + ":" + chatID + ":" + getCleanID(user)
+}
+
+// پرانی انٹریز میں Aliases نہیں، تب صرف User
+func muteAliases(m *MuteEntry) []string {
+	if len(m.Aliases) == 0 {
+		return []string{getCleanID(m.User)}
+	}
+	return m.Aliases
+}
+
+// muteMap میں ایک ہی انٹری کئی alias کیز پر ہوتی ہے، اس لیے یونیک لسٹ
+func uniqueMutes(match func(*MuteEntry) bool) []*MuteEntry {
+	seen := make(map[*MuteEntry]bool)
+	var list []*MuteEntry
+	muteMutex.RLock()
+	for _, m := range muteMap {
+		if !seen[m] && match(m) {
+			seen[m] = true
+			list = append(list, m)
+		}
+	}
+	muteMutex.RUnlock()
+	return list
+}
+
+// Redis Hash: "mutes:<botID>" → field "<chatID>|<user>"
+func muteRedisField(m *MuteEntry) string {
+	return m.ChatID + "|" + getCleanID(m.User)
+}
+
+func saveMute(m *MuteEntry) {
+	muteMutex.Lock()
+	for _, alias := range muteAliases(m) {
+		muteMap[muteKey(m.BotID, m.ChatID, alias)] = m
+	}
+	muteMutex.Unlock()
+
+	if rdb == nil {
+		return
+	}
+	jsonData, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+	if err := rdb.HSet(ctx, "mutes:"+m.BotID, muteRedisField(m), jsonData).Err(); err != nil {
+		fmt.Printf("⚠️ [REDIS ERROR] Failed to save mute: %v\n", err)
+	}
+}
+
+func removeMute(m *MuteEntry) {
+	muteMutex.Lock()
+	for _, alias := range muteAliases(m) {
+		delete(muteMap, muteKey(m.BotID, m.ChatID, alias))
+	}
+	muteMutex.Unlock()
+
+	if rdb != nil {
+		rdb.HDel(ctx, "mutes:"+m.BotID, muteRedisField(m))
+	}
+}
+
+func getMute(botID, chatID, user string) *MuteEntry {
+	muteMutex.RLock()
+	defer muteMutex.RUnlock()
+	return muteMap[muteKey(botID, chatID, user)]
+}
+
+// PN یا LID، جس شکل میں بھی یوزر آئے
+func findMute(client *whatsmeow.Client, chat, user types.JID) *MuteEntry {
+	botID := getCleanID(client.Store.ID.User)
+	if m := getMute(botID, chat.String(), user.User); m != nil {
+		return m
+	}
+	muteMutex.RLock()
+	empty := len(muteMap) == 0
+	muteMutex.RUnlock()
+	if empty {
+		return nil // ہاٹ پاتھ: کوئی میوٹ ہی نہیں تو LID لک اپ نہ کریں
+	}
+	for _, alias := range getUserAliases(client, user) {
+		if m := getMute(botID, chat.String(), alias); m != nil {
+			return m
+		}
+	}
+	return nil
+}
+
+// ری اسٹارٹ کے بعد تمام میوٹس ریڈیس سے واپس لوڈ کریں
+func loadMutes() {
+	if rdb == nil {
+		return
+	}
+	// Redis کالز لاک کے بغیر؛ میپ بن جائے تو ایک ساتھ ڈالیں
+	loaded := make(map[string]*MuteEntry)
+	count := 0
+	iter := rdb.Scan(ctx, 0, "mutes:*", 100).Iterator()
+	for iter.Next(ctx) {
+		vals, err := rdb.HGetAll(ctx, iter.Val()).Result()
+		if err != nil {
+			continue
+		}
+		for _, val := range vals {
+			var m MuteEntry
+			if json.Unmarshal([]byte(val), &m) != nil {
+				continue
+			}
+			entry := &m
+			for _, alias := range muteAliases(entry) {
+				loaded[muteKey(m.BotID, m.ChatID, alias)] = entry
+			}
+			count++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		fmt.Printf("⚠️ [MUTE] Could not load mutes: %v\n", err)
+		return
+	}
+
+	muteMutex.Lock()
+	for k, m := range loaded {
+		muteMap[k] = m
+	}
+	muteMutex.Unlock()
+
+	fmt.Printf("🔇 [MUTE] %d active mute(s) restored from Redis\n", count)
+}
+
+// ⏱️ ہر 30 سیکنڈ بعد ایکسپائرڈ میوٹس ختم کریں اور گروپ میں اطلاع دیں
+func startMuteWatcher() {
+	ticker := time.NewTicker(30 * time.Second)
+	go func() {
+		for range ticker.C {
+			now := time.Now()
+			expired := uniqueMutes(func(m *MuteEntry) bool { return now.After(m.ExpiresAt) })
+
+			for _, m := range expired {
+				removeMute(m)
+
+				clientsMutex.RLock()
+				botClient := activeClients[m.BotID]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue
+				}
+
+				chat, ok := parseJID(m.ChatID)
+				if !ok {
+					continue
+				}
+				target, _ := parseJID(m.User)
+				logModAction(botClient, chat, "unmute", "Mute expired", types.EmptyJID, target, "")
+				msg := fmt.Sprintf(`╔════════════════╗
+║ 🔊 UNMUTED
+╠════════════════╣
+║ 👤 User: @%s
+║ ⏱️ Mute expired
+╚════════════════╝`, getCleanID(m.User))
+				sendMentionText(botClient, chat, msg, []string{m.User})
+			}
+		}
+	}()
+}
+
+// 🛡️ میوٹڈ یوزر کا میسج فوراً ڈیلیٹ کریں (processMessage سے کال ہوتا ہے)
+func enforceMute(client *whatsmeow.Client, v *events.Message) bool {
+	m := findMute(client, v.Info.Chat, v.Info.Sender)
+	if m == nil || time.Now().After(m.ExpiresAt) {
+		return false
+	}
+
+	_, err := client.SendMessage(ctx, v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+	if err != nil {
+		fmt.Printf("⚠️ [MUTE] Revoke failed in %s: %v\n", v.Info.Chat.User, err)
+	} else {
+		logModAction(client, v.Info.Chat, "delete", "Muted: "+m.Reason, types.EmptyJID, v.Info.Sender, getText(v.Message))
+	}
+	return true
+}
+
+// ==================== کمانڈز ====================
+
+func handleMute(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	target, rest := resolveTarget(v, args)
+	if target.User == "" || len(rest) == 0 {
+		msg := `╔════════════════╗
+║ ⚠️ INVALID
+╠════════════════
+║ Usage:
+║ .mute @user 30m [reason]
+║
+║ Units: s, m, h, d, w
+╚════════════════`
+		replyMessage(client, v, msg)
+		return
+	}
+
+	dur, ok := parseDurationArg(rest[0])
+	if !ok {
+		replyMessage(client, v, "❌ Invalid duration. Example: 10m, 2h, 1d")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	if getCleanID(target.User) == botID || isAdmin(client, v.Info.Chat, target) {
+		replyMessage(client, v, "❌ Admins cannot be muted.")
+		return
+	}
+
+	reason := strings.Join(rest[1:], " ")
+	if reason == "" {
+		reason = "No reason"
+	}
+
+	m := &MuteEntry{
+		BotID:     botID,
+		ChatID:    v.Info.Chat.String(),
+		User:      target.String(),
+		Aliases:   getUserAliases(client, target),
+		Reason:    reason,
+		By:        v.Info.Sender.User,
+		ExpiresAt: time.Now().Add(dur),
+	}
+	// دوسری شکل (PN/LID) میں پرانا میوٹ ہو تو اسے بدل دیں
+	if old := findMute(client, v.Info.Chat, target); old != nil {
+		removeMute(old)
+	}
+	saveMute(m)
+	logModAction(client, v.Info.Chat, "mute", fmt.Sprintf("%s (%s)", reason, formatDuration(dur)), v.Info.Sender, target, "")
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🔇 MUTED
+╠════════════════╣
+║ 👤 User: @%s
+║ ⏱️ Time: %s
+║ 📝 Reason: %s
+╚════════════════╝`, target.User, formatDuration(dur), reason)
+	sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+}
+
+func handleUnmute(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	target, _ := resolveTarget(v, args)
+	if target.User == "" {
+		replyMessage(client, v, "⚠️ Usage: .unmute @user")
+		return
+	}
+
+	m := findMute(client, v.Info.Chat, target)
+	if m == nil {
+		replyMessage(client, v, "ℹ️ This user is not muted.")
+		return
+	}
+	removeMute(m)
+	logModAction(client, v.Info.Chat, "unmute", "Manual command", v.Info.Sender, target, "")
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🔊 UNMUTED
+╠════════════════╣
+║ 👤 User: @%s
+║ 👮 By: @%s
+╚════════════════╝`, target.User, v.Info.Sender.User)
+	sendMentionText(client, v.Info.Chat, msg, []string{target.String(), v.Info.Sender.String()})
+}
+
+func handleMuteList(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+
+	list := uniqueMutes(func(m *MuteEntry) bool { return m.BotID == botID && m.ChatID == chatID })
+
+	if len(list) == 0 {
+		replyMessage(client, v, "📭 No muted members in this group.")
+		return
+	}
+
+	out := "╔════════════════╗\n"
+	out += "║ 🔇 MUTED MEMBERS\n"
+	out += "╠════════════════╣\n"
+	var mentions []string
+	for i, m := range list {
+		left := time.Until(m.ExpiresAt)
+		if left < 0 {
+			left = 0
+		}
+		out += fmt.Sprintf("║ %d. @%s\n║    ⏱️ %s left | %s\n", i+1, getCleanID(m.User), formatDuration(left), m.Reason)
+		mentions = append(mentions, m.User)
+	}
+	out += fmt.Sprintf("║ 📊 Total: %d\n", len(list))
+	out += "╚════════════════╝"
+
+	sendMentionText(client, v.Info.Chat, out, mentions)
+}
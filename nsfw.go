@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🔞 NSFW IMAGE FILTER
+// ════════════════════════════════════════════════════════════════
+// تصویر/اسٹیکر کا تھمب نیل (نہ ہو تو پوری فائل) لوکل ONNX ماڈل سے چیک
+// ہوتا ہے (nsfw_classify.py)۔ نتیجہ فائل کے SHA256 پر کیش ہوتا ہے تاکہ
+// فارورڈ شدہ وہی تصویر دوبارہ ماڈل سے نہ گزرے۔
+//
+// ENV: NSFW_MODEL (default store/nsfw.onnx)
+//      NSFW_CLASSIFIER=stub  → ماڈل کے بغیر ٹیسٹنگ، اسکور NSFW_STUB_SCORE سے
+
+// 0..1 اسکور واپس کرتا ہے
+type nsfwClassifierFunc func(img []byte) (float64, error)
+
+var classifyNSFW = selectNSFWClassifier()
+
+var (
+	nsfwCache      = make(map[string]float64) // sha256 hex -> score
+	nsfwCacheMutex sync.RWMutex
+	nsfwSlots      = make(chan struct{}, 2) // ایک وقت میں زیادہ سے زیادہ 2 ماڈل پروسیس
+
+	// ماڈل فائل بعد میں رکھی جا سکتی ہے، اس لیے وقفے وقفے سے دوبارہ چیک
+	nsfwModelMutex   sync.Mutex
+	nsfwModelChecked time.Time
+	nsfwModelErr     error
+)
+
+var errNSFWModelMissing = errors.New("model not found")
+
+const (
+	defaultNSFWThreshold = 70 // فیصد
+	nsfwCacheTTL         = 7 * 24 * time.Hour
+	nsfwCacheMaxEntries  = 5000
+	nsfwModelRecheck     = time.Minute
+)
+
+func selectNSFWClassifier() nsfwClassifierFunc {
+	if strings.ToLower(os.Getenv("NSFW_CLASSIFIER")) == "stub" {
+		fmt.Println("🔞 [NSFW] Using stub classifier")
+		return stubNSFWClassifier
+	}
+	return onnxNSFWClassifier
+}
+
+// 🧪 ماڈل کے بغیر پورا فلو چیک کرنے کے لیے
+func stubNSFWClassifier(img []byte) (float64, error) {
+	if len(img) == 0 {
+		return 0, errors.New("empty image")
+	}
+	score, _ := strconv.ParseFloat(os.Getenv("NSFW_STUB_SCORE"), 64)
+	return score, nil
+}
+
+func nsfwModelPath() string {
+	if p := os.Getenv("NSFW_MODEL"); p != "" {
+		return p
+	}
+	return "store/nsfw.onnx"
+}
+
+// nil = ماڈل موجود ہے؛ نتیجہ nsfwModelRecheck تک کیش، لاگ صرف حالت بدلنے پر
+func nsfwModelStatus() error {
+	nsfwModelMutex.Lock()
+	defer nsfwModelMutex.Unlock()
+
+	if !nsfwModelChecked.IsZero() && time.Since(nsfwModelChecked) < nsfwModelRecheck {
+		return nsfwModelErr
+	}
+	wasMissing := nsfwModelErr != nil
+
+	var err error
+	if _, statErr := os.Stat(nsfwModelPath()); statErr != nil {
+		err = fmt.Errorf("%w at %s", errNSFWModelMissing, nsfwModelPath())
+	}
+	switch {
+	case err != nil && (!wasMissing || nsfwModelChecked.IsZero()):
+		fmt.Printf("⚠️ [NSFW] %v (images are not scanned until it is added, re-checking every %s)\n", err, nsfwModelRecheck)
+	case err == nil && wasMissing:
+		fmt.Printf("✅ [NSFW] Model found at %s, scanning enabled\n", nsfwModelPath())
+	}
+	nsfwModelErr = err
+	nsfwModelChecked = time.Now()
+	return err
+}
+
+func onnxNSFWClassifier(img []byte) (float64, error) {
+	if err := nsfwModelStatus(); err != nil {
+		return 0, err
+	}
+
+	nsfwSlots <- struct{}{}
+	defer func() { <-nsfwSlots }()
+
+	input, err := os.CreateTemp("", "nsfw_*.img")
+	if err != nil {
+		return 0, err
+	}
+	inputPath := input.Name()
+	defer os.Remove(inputPath)
+	_, err = input.Write(img)
+	if closeErr := input.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	cmdCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(cmdCtx, "python3", "nsfw_classify.py", nsfwModelPath(), inputPath).Output()
+	if err != nil {
+		return 0, fmt.Errorf("classifier failed: %v", err)
+	}
+	return strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+}
+
+func getCachedNSFW(key string) (float64, bool) {
+	nsfwCacheMutex.RLock()
+	score, ok := nsfwCache[key]
+	nsfwCacheMutex.RUnlock()
+	if ok {
+		return score, true
+	}
+	if rdb == nil {
+		return 0, false
+	}
+	val, err := rdb.Get(ctx, "nsfw:"+key).Result()
+	if err != nil {
+		return 0, false
+	}
+	score, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, false
+	}
+	setCachedNSFW(key, score, false)
+	return score, true
+}
+
+func setCachedNSFW(key string, score float64, persist bool) {
+	nsfwCacheMutex.Lock()
+	if len(nsfwCache) >= nsfwCacheMaxEntries {
+		nsfwCache = make(map[string]float64) // سادہ ری سیٹ، Redis میں باقی رہتا ہے
+	}
+	nsfwCache[key] = score
+	nsfwCacheMutex.Unlock()
+
+	if persist && rdb != nil {
+		rdb.Set(ctx, "nsfw:"+key, strconv.FormatFloat(score, 'f', 4, 64), nsfwCacheTTL)
+	}
+}
+
+// تصویر یا اسٹیکر کا NSFW اسکور (فیصد)؛ false = چیک نہیں ہو سکا
+func getNSFWScore(client *whatsmeow.Client, v *events.Message) (int, bool) {
+	var (
+		sha   []byte
+		thumb []byte
+		media whatsmeow.DownloadableMessage
+	)
+	if img := v.Message.GetImageMessage(); img != nil {
+		sha, thumb, media = img.GetFileSHA256(), img.GetJPEGThumbnail(), img
+	} else if st := v.Message.GetStickerMessage(); st != nil {
+		sha, thumb, media = st.GetFileSHA256(), st.GetPngThumbnail(), st
+	} else {
+		return 0, false
+	}
+
+	key := hex.EncodeToString(sha)
+	if key != "" {
+		if score, ok := getCachedNSFW(key); ok {
+			return int(score * 100), true
+		}
+	}
+
+	data := thumb
+	if len(data) == 0 {
+		var err error
+		data, err = client.Download(context.Background(), media)
+		if err != nil {
+			fmt.Printf("⚠️ [NSFW] Download failed: %v\n", err)
+			return 0, false
+		}
+	}
+
+	score, err := classifyNSFW(data)
+	if err != nil {
+		if !errors.Is(err, errNSFWModelMissing) {
+			fmt.Printf("⚠️ [NSFW] %v\n", err)
+		}
+		return 0, false
+	}
+
+	if key != "" {
+		setCachedNSFW(key, score, true)
+	}
+	return int(score * 100), true
+}
+
+// checkSecurity سے کال ہوتا ہے
+func checkNSFW(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+	if !s.NSFW || (v.Message.ImageMessage == nil && v.Message.StickerMessage == nil) {
+		return false
+	}
+	score, ok := getNSFWScore(client, v)
+	if !ok {
+		return false
+	}
+
+	threshold := s.NSFWThreshold
+	if threshold <= 0 {
+		threshold = defaultNSFWThreshold
+	}
+	if score < threshold {
+		return false
+	}
+
+	action := s.NSFWAction
+	if action == "" {
+		action = "delete"
+	}
+	takeSecurityAction(client, v, s, action, fmt.Sprintf("Explicit image (%d%%)", score), botID)
+	return true
+}
+
+// ==================== کمانڈ ====================
+
+func handleAntiNSFW(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	arg := ""
+	if len(args) > 0 {
+		arg = strings.ToLower(args[0])
+	}
+	val := ""
+	if len(args) > 1 {
+		val = strings.ToLower(args[1])
+	}
+
+	switch arg {
+	case "setup":
+		startWizard(client, v, "antinsfw", botID, v.Info.Chat.String())
+		return
+	case "on":
+		s.NSFW = true
+		replyMessage(client, v, "✅ *NSFW Filter:* ON")
+	case "off":
+		s.NSFW = false
+		replyMessage(client, v, "❌ *NSFW Filter:* OFF")
+	case "threshold":
+		n, err := strconv.Atoi(strings.TrimSuffix(val, "%"))
+		if err != nil || n < 1 || n > 100 {
+			replyMessage(client, v, "⚠️ Usage: .antinsfw threshold <1-100>")
+			return
+		}
+		s.NSFWThreshold = n
+		replyMessage(client, v, fmt.Sprintf("✅ NSFW threshold set to %d%%", n))
+	case "action":
+		switch val {
+		case "delete":
+			s.NSFWAction = "delete"
+		case "warn":
+			s.NSFWAction = "deletewarn"
+		case "kick":
+			s.NSFWAction = "deletekick"
+		default:
+			replyMessage(client, v, "⚠️ Usage: .antinsfw action delete | warn | kick")
+			return
+		}
+		replyMessage(client, v, "✅ NSFW action set to "+actionLabel(s.NSFWAction))
+	default:
+		status := "🔴 DISABLED"
+		if s.NSFW {
+			status = "🟢 ENABLED"
+		}
+		threshold := s.NSFWThreshold
+		if threshold <= 0 {
+			threshold = defaultNSFWThreshold
+		}
+		model := "✅ Ready"
+		if strings.ToLower(os.Getenv("NSFW_CLASSIFIER")) == "stub" {
+			model = "🧪 Stub"
+		} else if err := nsfwModelStatus(); err != nil {
+			model = "❌ Missing (not scanning)"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🔞 NSFW FILTER
+╠════════════════╣
+║ Status: %s
+║ Threshold: %d%%
+║ Action: %s
+║ Model: %s
+╠════════════════╣
+║ .antinsfw on/off
+║ .antinsfw setup
+║ .antinsfw threshold 70
+║ .antinsfw action delete/warn/kick
+╚════════════════╝`, status, threshold, actionLabel(s.NSFWAction), model)
+		replyMessage(client, v, msg)
+		return
+	}
+	saveGroupSettings(botID, s)
+}
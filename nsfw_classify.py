@@ -0,0 +1,43 @@
+#!/usr/bin/env python3
+# 🔞 NSFW image classifier (CPU, ONNX Runtime)
+# Usage: python3 nsfw_classify.py <model.onnx> <image>
+# Prints a single float 0..1 = probability that the image is NSFW.
+#
+# Expects a 5-class model (drawings, hentai, neutral, porn, sexy) with a
+# 224x224 RGB NHWC float input, e.g. the open-source "nsfw_model" exported
+# to ONNX. Pillow and numpy come with rembg, so nothing extra is installed.
+
+import sys
+
+import numpy as np
+import onnxruntime as ort
+from PIL import Image
+
+NSFW_CLASSES = (1, 3, 4)  # hentai, porn, sexy
+
+
+def main():
+    if len(sys.argv) != 3:
+        print("usage: nsfw_classify.py <model.onnx> <image>", file=sys.stderr)
+        sys.exit(2)
+
+    model_path, image_path = sys.argv[1], sys.argv[2]
+
+    img = Image.open(image_path).convert("RGB").resize((224, 224))
+    x = np.asarray(img, dtype=np.float32)[None, ...] / 255.0
+
+    sess = ort.InferenceSession(model_path, providers=["CPUExecutionProvider"])
+    inp = sess.get_inputs()[0]
+    if len(inp.shape) == 4 and inp.shape[1] == 3:  # NCHW ماڈل
+        x = x.transpose(0, 3, 1, 2)
+
+    probs = sess.run(None, {inp.name: x})[0][0]
+    if probs.min() < 0 or abs(float(probs.sum()) - 1.0) > 1e-3:  # logits -> softmax
+        e = np.exp(probs - probs.max())
+        probs = e / e.sum()
+
+    print("%.4f" % float(sum(probs[i] for i in NSFW_CLASSES)))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// کنیکٹ نہ ہونے والا کلائنٹ: بھیجنے کی کوششیں ایرر دیتی ہیں، ایکشن کی گنتی پھر بھی ہوتی ہے
+func offlineTestClient(t *testing.T) *whatsmeow.Client {
+	t.Helper()
+	botJID := types.NewJID("923000000001", types.DefaultUserServer)
+	return whatsmeow.NewClient(&store.Device{ID: &botJID}, nil)
+}
+
+func TestCheckSecurityNSFW(t *testing.T) {
+	t.Setenv("NSFW_CLASSIFIER", "stub")
+	prevClassifier := classifyNSFW
+	classifyNSFW = selectNSFWClassifier()
+	t.Cleanup(func() { classifyNSFW = prevClassifier })
+
+	client := offlineTestClient(t)
+	botID := getCleanID(client.Store.ID.User)
+	chat := types.NewJID("120363000000000001", types.GroupServer)
+	sender := types.NewJID("923111111111", types.DefaultUserServer)
+
+	tests := []struct {
+		name      string
+		stubScore string
+		threshold int
+		msg       *waProto.Message
+		warned    bool
+	}{
+		{"explicit image over threshold", "0.92", 70, imageMsg("sha-a"), true},
+		{"safe image under threshold", "0.15", 70, imageMsg("sha-b"), false},
+		{"custom threshold", "0.45", 40, imageMsg("sha-c"), true},
+		{"explicit sticker", "0.99", 0, &waProto.Message{StickerMessage: &waProto.StickerMessage{
+			FileSHA256:   []byte("sha-d"),
+			PngThumbnail: []byte("png"),
+		}}, true},
+		{"text is ignored", "0.99", 70, &waProto.Message{Conversation: proto.String("hello")}, false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NSFW_STUB_SCORE", tt.stubScore)
+
+			groupChat := chat
+			groupChat.User += string(rune('a' + i)) // ہر کیس کی الگ سیٹنگز
+			s := getGroupSettings(botID, groupChat.String())
+			s.NSFW = true
+			s.NSFWThreshold = tt.threshold
+			s.NSFWAction = "deletewarn"
+			s.Warnings = nil
+			saveGroupSettings(botID, s)
+
+			v := &events.Message{
+				Info: types.MessageInfo{
+					MessageSource: types.MessageSource{Chat: groupChat, Sender: sender, IsGroup: true},
+					ID:            "TEST" + tt.stubScore,
+					Timestamp:     time.Now(),
+				},
+				Message: tt.msg,
+			}
+			checkSecurity(client, v)
+
+			if got := s.Warnings[sender.String()] == 1; got != tt.warned {
+				t.Errorf("warned = %v, want %v (warnings %v)", got, tt.warned, s.Warnings)
+			}
+		})
+	}
+}
+
+func TestCheckSecurityNSFWDisabled(t *testing.T) {
+	t.Setenv("NSFW_CLASSIFIER", "stub")
+	t.Setenv("NSFW_STUB_SCORE", "0.99")
+	prevClassifier := classifyNSFW
+	classifyNSFW = selectNSFWClassifier()
+	t.Cleanup(func() { classifyNSFW = prevClassifier })
+
+	client := offlineTestClient(t)
+	chat := types.NewJID("120363000000000099", types.GroupServer)
+	sender := types.NewJID("923111111112", types.DefaultUserServer)
+	s := getGroupSettings(getCleanID(client.Store.ID.User), chat.String())
+	s.NSFW = false
+	s.NSFWAction = "deletewarn"
+	saveGroupSettings(getCleanID(client.Store.ID.User), s)
+
+	checkSecurity(client, &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{Chat: chat, Sender: sender, IsGroup: true},
+			ID:            "TESTOFF",
+		},
+		Message: imageMsg("sha-off"),
+	})
+	if len(s.Warnings) != 0 {
+		t.Errorf("filter is off but sender was warned: %v", s.Warnings)
+	}
+}
+
+func imageMsg(sha string) *waProto.Message {
+	return &waProto.Message{ImageMessage: &waProto.ImageMessage{
+		FileSHA256:    []byte(sha),
+		JPEGThumbnail: []byte("jpeg"),
+	}}
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📊 NATIVE POLLS
+// ════════════════════════════════════════════════════════════════
+// Poll:      Redis "poll:<msgID>" (JSON، 30 دن)
+// Votes:     Redis Hash "poll_votes:<msgID>" (ووٹر JID -> منتخب آپشنز کی لسٹ)
+// Recent:    Redis List "polls:<chatID>" (تازہ ترین پہلے)
+// Deadlines: Redis Sorted Set "poll_deadlines" (score = unix، member = msgID)
+// ووٹ encrypted آتے ہیں؛ whatsmeow بھیجے گئے پول کا MessageSecret خود محفوظ کرتا ہے
+// اس لیے DecryptPollVote صرف اسی بوٹ کے بنائے پولز پر چلتا ہے۔
+
+type Poll struct {
+	ID       string    `json:"id"`
+	BotID    string    `json:"bot_id"`
+	ChatID   string    `json:"chat_id"`
+	Question string    `json:"question"`
+	Options  []string  `json:"options"`
+	Multi    bool      `json:"multi"`
+	Creator  string    `json:"creator"`
+	Created  time.Time `json:"created"`
+	Deadline time.Time `json:"deadline"`
+	Closed   bool      `json:"closed"`
+}
+
+const (
+	pollTTL        = 30 * 24 * time.Hour
+	pollMaxOptions = 12
+	pollRecentMax  = 20
+	pollVotersMax  = 10
+)
+
+func getPoll(id string) (*Poll, bool) {
+	if rdb == nil || id == "" {
+		return nil, false
+	}
+	val, err := rdb.Get(ctx, "poll:"+id).Result()
+	if err != nil {
+		return nil, false
+	}
+	var p Poll
+	if json.Unmarshal([]byte(val), &p) != nil {
+		return nil, false
+	}
+	return &p, true
+}
+
+func savePoll(p *Poll) {
+	jsonData, _ := json.Marshal(p)
+	rdb.Set(ctx, "poll:"+p.ID, jsonData, pollTTL)
+}
+
+// `"Question" | opt1 | opt2` سے پہلے: 2h (آٹو کلوز) اور/یا multi
+func parsePollArgs(text string) (question string, options []string, deadline time.Duration, multi bool) {
+	for {
+		text = strings.TrimSpace(text)
+		word := text
+		if idx := strings.IndexAny(text, " \n"); idx >= 0 {
+			word = text[:idx]
+		}
+		if strings.ToLower(word) == "multi" {
+			multi = true
+		} else if d, ok := parseDurationArg(word); ok {
+			deadline = d
+		} else {
+			break
+		}
+		text = text[len(word):]
+	}
+
+	parts := strings.Split(text, "|")
+	question = strings.Trim(strings.TrimSpace(parts[0]), `"“”`)
+	seen := make(map[string]bool)
+	for _, part := range parts[1:] {
+		opt := strings.TrimSpace(part)
+		if opt == "" || seen[opt] {
+			continue
+		}
+		seen[opt] = true
+		options = append(options, opt)
+	}
+	return
+}
+
+// processMessage سے؛ true = یہ پول ووٹ تھا
+func handlePollVote(client *whatsmeow.Client, v *events.Message) bool {
+	update := v.Message.GetPollUpdateMessage()
+	if update == nil {
+		return false
+	}
+	poll, ok := getPoll(update.GetPollCreationMessageKey().GetID())
+	if !ok || poll.Closed {
+		return true
+	}
+	// کسی اور بوٹ کا پول؛ وہی ڈکرپٹ کر سکتا ہے
+	if poll.BotID != getCleanID(client.Store.ID.User) {
+		return true
+	}
+
+	vote, err := client.DecryptPollVote(context.Background(), v)
+	if err != nil {
+		fmt.Printf("⚠️ [POLL] Could not decrypt vote on %s: %v\n", poll.ID, err)
+		return true
+	}
+
+	hashes := whatsmeow.HashPollOptions(poll.Options)
+	var selected []int
+	for _, h := range vote.GetSelectedOptions() {
+		for i, oh := range hashes {
+			if bytes.Equal(h, oh) {
+				selected = append(selected, i)
+				break
+			}
+		}
+	}
+
+	voter := v.Info.Sender.ToNonAD().String()
+	key := "poll_votes:" + poll.ID
+	if len(selected) == 0 {
+		rdb.HDel(ctx, key, voter) // ووٹ واپس لیا گیا
+	} else {
+		jsonData, _ := json.Marshal(selected)
+		rdb.HSet(ctx, key, voter, jsonData)
+		rdb.Expire(ctx, key, pollTTL)
+	}
+	return true
+}
+
+func pollTally(p *Poll) ([]int, [][]string, int) {
+	counts := make([]int, len(p.Options))
+	voters := make([][]string, len(p.Options))
+	raw, _ := rdb.HGetAll(ctx, "poll_votes:"+p.ID).Result()
+
+	// ترتیب ثابت رہے
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, voter := range keys {
+		var selected []int
+		if json.Unmarshal([]byte(raw[voter]), &selected) != nil {
+			continue
+		}
+		for _, i := range selected {
+			if i >= 0 && i < len(counts) {
+				counts[i]++
+				voters[i] = append(voters[i], voter)
+			}
+		}
+	}
+	return counts, voters, len(keys)
+}
+
+func formatPollResults(p *Poll) (string, []string) {
+	counts, voters, total := pollTally(p)
+
+	title := "📊 POLL RESULTS"
+	if p.Closed {
+		title = "🔒 POLL CLOSED"
+	}
+	out := "╔════════════════╗\n"
+	out += "║ " + title + "\n"
+	out += "╠════════════════╣\n"
+	out += "║ ❓ " + p.Question + "\n"
+	out += "╠════════════════╣\n"
+
+	var mentions []string
+	for i, opt := range p.Options {
+		percent := 0
+		if total > 0 {
+			percent = counts[i] * 100 / total
+		}
+		out += fmt.Sprintf("║ %d. %s\n", i+1, opt)
+		out += fmt.Sprintf("║    %s %d (%d%%)\n", xpProgressBar(percent, 100), counts[i], percent)
+		for j, voter := range voters[i] {
+			if j >= pollVotersMax {
+				out += fmt.Sprintf("║      ... +%d more\n", len(voters[i])-pollVotersMax)
+				break
+			}
+			jid, err := types.ParseJID(voter)
+			if err != nil {
+				continue
+			}
+			out += "║      • @" + jid.User + "\n"
+			mentions = append(mentions, voter)
+		}
+	}
+	out += "╠════════════════╣\n"
+	out += fmt.Sprintf("║ 👥 Voters: %d\n", total)
+	if !p.Deadline.IsZero() && !p.Closed {
+		out += "║ ⏳ Closes in: " + formatDuration(time.Until(p.Deadline)) + "\n"
+	}
+	out += "╚════════════════╝"
+	return out, mentions
+}
+
+func closePoll(client *whatsmeow.Client, p *Poll) {
+	p.Closed = true
+	savePoll(p)
+	rdb.ZRem(ctx, "poll_deadlines", p.ID)
+
+	chat, ok := parseJID(p.ChatID)
+	if !ok {
+		return
+	}
+	out, mentions := formatPollResults(p)
+	sendMentionText(client, chat, out, mentions)
+}
+
+func startPollWatcher() {
+	ticker := time.NewTicker(30 * time.Second)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			now := strconv.FormatInt(time.Now().Unix(), 10)
+			ids, err := rdb.ZRangeByScore(ctx, "poll_deadlines", &redis.ZRangeBy{Min: "-inf", Max: now}).Result()
+			if err != nil {
+				continue
+			}
+			for _, id := range ids {
+				p, ok := getPoll(id)
+				if !ok || p.Closed {
+					rdb.ZRem(ctx, "poll_deadlines", id)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[p.BotID]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue // بوٹ آن لائن آنے پر بند کریں گے
+				}
+				closePoll(botClient, p)
+				fmt.Printf("📊 [POLL] Auto-closed %s in %s\n", p.ID, p.ChatID)
+			}
+		}
+	}()
+}
+
+// ریپلائی کیا گیا پول، ورنہ اس چیٹ کا تازہ ترین
+func findTargetPoll(v *events.Message) (*Poll, bool) {
+	if ci := messageContextInfo(v.Message); ci != nil && ci.GetStanzaID() != "" {
+		if p, ok := getPoll(ci.GetStanzaID()); ok {
+			return p, true
+		}
+	}
+	id, err := rdb.LIndex(ctx, "polls:"+v.Info.Chat.String(), 0).Result()
+	if err != nil {
+		return nil, false
+	}
+	return getPoll(id)
+}
+
+// ==================== کمانڈز ====================
+
+// .poll [2h] [multi] "Question" | opt1 | opt2 ... | .poll close
+func handlePoll(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+
+	if len(args) == 1 && strings.ToLower(args[0]) == "close" {
+		p, ok := findTargetPoll(v)
+		if !ok {
+			replyMessage(client, v, "📭 No poll found. Reply to a poll or create one with .poll")
+			return
+		}
+		if p.Closed {
+			replyMessage(client, v, "🔒 This poll is already closed.")
+			return
+		}
+		closePoll(client, p)
+		return
+	}
+
+	question, options, deadline, multi := parsePollArgs(commandText(v))
+	if question == "" || len(options) < 2 {
+		msg := `╔════════════════╗
+║ 📊 POLL
+╠════════════════╣
+║ .poll "Question" | opt1 | opt2
+║ .poll 2h "Question" | a | b
+║ .poll multi "Question" | a | b | c
+║ .poll close (reply / latest)
+║ .pollresult (reply / latest)
+╚════════════════╝`
+		replyMessage(client, v, msg)
+		return
+	}
+	if len(options) > pollMaxOptions {
+		replyMessage(client, v, fmt.Sprintf("❌ A poll can have at most %d options.", pollMaxOptions))
+		return
+	}
+
+	selectable := 1
+	if multi {
+		selectable = 0 // 0 = جتنے چاہیں
+	}
+	resp, err := client.SendMessage(context.Background(), v.Info.Chat, client.BuildPollCreation(question, options, selectable))
+	if err != nil {
+		replyMessage(client, v, "❌ Failed to send poll: "+err.Error())
+		return
+	}
+
+	p := &Poll{
+		ID:       resp.ID,
+		BotID:    getCleanID(client.Store.ID.User),
+		ChatID:   v.Info.Chat.String(),
+		Question: question,
+		Options:  options,
+		Multi:    multi,
+		Creator:  v.Info.Sender.ToNonAD().String(),
+		Created:  time.Now(),
+	}
+	if deadline > 0 {
+		p.Deadline = time.Now().Add(deadline)
+		rdb.ZAdd(ctx, "poll_deadlines", redis.Z{Score: float64(p.Deadline.Unix()), Member: p.ID})
+	}
+	savePoll(p)
+	rdb.LPush(ctx, "polls:"+p.ChatID, p.ID)
+	rdb.LTrim(ctx, "polls:"+p.ChatID, 0, pollRecentMax-1)
+
+	if deadline > 0 {
+		replyMessage(client, v, "⏳ Poll will close automatically in "+formatDuration(deadline))
+	}
+}
+
+// .pollresult (پول کو ریپلائی یا تازہ ترین)
+func handlePollResult(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	p, ok := findTargetPoll(v)
+	if !ok {
+		replyMessage(client, v, "📭 No poll found. Reply to a poll or create one with .poll")
+		return
+	}
+	out, mentions := formatPollResults(p)
+	sendMentionText(client, v.Info.Chat, out, mentions)
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🚨 ANTI-RAID & GROUP LOCKDOWN
+// ════════════════════════════════════════════════════════════════
+// Joins are counted per group in a sliding window. When the threshold is
+// crossed the group goes announce-only; the lockdown is stored in the
+// Redis hash "lockdowns" (field "<botID>|<chatID>" → unix expiry) so the
+// watcher can lift it after the cooldown, even across restarts.
+// "lockdown_prev" (same field → "1"/"0") remembers whether the group was
+// already announce-only, so lifting the lockdown restores that state.
+// Scheduled open/close during a lockdown only rewrites that saved state.
+
+type raidJoin struct {
+	User types.JID
+	At   time.Time
+}
+
+var (
+	raidTracker = make(map[string][]raidJoin) // botID:chatID -> recent joins
+	raidMutex   sync.Mutex
+)
+
+const (
+	defaultRaidThreshold = 15
+	defaultRaidWindow    = 60 // seconds
+	defaultRaidCooldown  = 30 // minutes
+)
+
+func raidConfig(s *GroupSettings) (int, time.Duration, time.Duration) {
+	threshold := s.RaidThreshold
+	if threshold <= 0 {
+		threshold = defaultRaidThreshold
+	}
+	window := s.RaidWindow
+	if window <= 0 {
+		window = defaultRaidWindow
+	}
+	cooldown := s.RaidCooldown
+	if cooldown <= 0 {
+		cooldown = defaultRaidCooldown
+	}
+	return threshold, time.Duration(window) * time.Second, time.Duration(cooldown) * time.Minute
+}
+
+// 📈 Join ایونٹ ریکارڈ کریں۔ ریڈ ہو تو لاک ڈاؤن اور true واپس
+func trackJoinsForRaid(client *whatsmeow.Client, s *GroupSettings, v *events.GroupInfo) bool {
+	botID := getCleanID(client.Store.ID.User)
+	key := botID + ":" + v.JID.String()
+	threshold, window, cooldown := raidConfig(s)
+	now := time.Now()
+
+	raidMutex.Lock()
+	var recent []raidJoin
+	for _, j := range raidTracker[key] {
+		if now.Sub(j.At) <= window {
+			recent = append(recent, j)
+		}
+	}
+	for _, joined := range v.Join {
+		recent = append(recent, raidJoin{User: joined, At: now})
+	}
+
+	if len(recent) < threshold || isLockedDown(botID, v.JID.String()) {
+		raidTracker[key] = recent
+		raidMutex.Unlock()
+		return false
+	}
+	// برسٹ پکڑ لیا، ٹریکر صاف کریں تاکہ دوبارہ ٹرگر نہ ہو
+	delete(raidTracker, key)
+	raidMutex.Unlock()
+
+	fmt.Printf("🚨 [RAID] %d joins in %s detected in %s\n", len(recent), window, v.JID.User)
+	startLockdown(client, v.JID, cooldown, "Raid detected")
+
+	extra := ""
+	if s.RaidRevokeLink {
+		if _, err := client.GetGroupInviteLink(context.Background(), v.JID, true); err == nil {
+			extra += "║ 🔗 Invite link revoked\n"
+		}
+	}
+	if s.RaidKick {
+		var burst []types.JID
+		for _, j := range recent {
+			if !isAdmin(client, v.JID, j.User) {
+				burst = append(burst, j.User)
+			}
+		}
+		kicked := 0
+		for i := 0; i < len(burst); i += 5 {
+			end := i + 5
+			if end > len(burst) {
+				end = len(burst)
+			}
+			if _, err := client.UpdateGroupParticipants(context.Background(), v.JID, burst[i:end], whatsmeow.ParticipantChangeRemove); err == nil {
+				kicked += end - i
+				for _, u := range burst[i:end] {
+					logModAction(client, v.JID, "kick", "Raid burst", types.EmptyJID, u, "")
+				}
+			}
+			time.Sleep(1 * time.Second)
+		}
+		extra += fmt.Sprintf("║ 👢 Kicked: %d newcomers\n", kicked)
+	}
+
+	msg := fmt.Sprintf("╔════════════════╗\n"+
+		"║ 🚨 RAID DETECTED\n"+
+		"╠════════════════╣\n"+
+		"║ 📈 %d joins in %s\n"+
+		"║ 🔒 Group locked\n"+
+		"%s"+
+		"║ ⏱️ Auto unlock: %s\n"+
+		"║ 🔓 .lockdown off\n"+
+		"╚════════════════╝", len(recent), formatDuration(window), extra, formatDuration(cooldown))
+	sendMentionText(client, v.JID, msg, nil)
+
+	notifyOwner(client, fmt.Sprintf("🚨 *RAID ALERT*\n👥 Group: %s\n📈 %d joins in %s\n🔒 Lockdown active for %s",
+		v.JID.String(), len(recent), formatDuration(window), formatDuration(cooldown)))
+	return true
+}
+
+func lockdownField(botID, chatID string) string {
+	return botID + "|" + chatID
+}
+
+func isLockedDown(botID, chatID string) bool {
+	if rdb == nil {
+		return false
+	}
+	n, err := rdb.HExists(ctx, "lockdowns", lockdownField(botID, chatID)).Result()
+	return err == nil && n
+}
+
+func startLockdown(client *whatsmeow.Client, chat types.JID, cooldown time.Duration, reason string) error {
+	botID := getCleanID(client.Store.ID.User)
+	field := lockdownField(botID, chat.String())
+	// پہلے سے announce-only تھا؟ (دوبارہ لاک ڈاؤن پر اصل حالت نہ بدلیں)
+	if rdb != nil && !isLockedDown(botID, chat.String()) {
+		prev := "0"
+		if info, err := client.GetGroupInfo(context.Background(), chat); err == nil && info.IsAnnounce {
+			prev = "1"
+		}
+		rdb.HSet(ctx, "lockdown_prev", field, prev)
+	}
+
+	if err := client.SetGroupAnnounce(context.Background(), chat, true); err != nil {
+		fmt.Printf("⚠️ [LOCKDOWN] Could not lock %s: %v\n", chat.User, err)
+		return err
+	}
+	if rdb != nil {
+		expiry := time.Now().Add(cooldown).Unix()
+		rdb.HSet(ctx, "lockdowns", field, expiry)
+	}
+	fmt.Printf("🔒 [LOCKDOWN] %s locked for %s (%s)\n", chat.User, cooldown, reason)
+	logModAction(client, chat, "lockdown", fmt.Sprintf("%s (%s)", reason, formatDuration(cooldown)), types.EmptyJID, types.EmptyJID, "")
+	return nil
+}
+
+// لاک ڈاؤن ختم ہونے پر کون سی حالت بحال ہو (شیڈول کی ٹرانزیشن یہاں رک جاتی ہے)
+func setLockdownRestoreState(botID, chatID string, announce bool) {
+	if rdb == nil {
+		return
+	}
+	prev := "0"
+	if announce {
+		prev = "1"
+	}
+	rdb.HSet(ctx, "lockdown_prev", lockdownField(botID, chatID), prev)
+}
+
+// reopened = false جب گروپ لاک ڈاؤن سے پہلے ہی announce-only تھا
+func endLockdown(client *whatsmeow.Client, chat types.JID) (reopened bool, err error) {
+	botID := getCleanID(client.Store.ID.User)
+	field := lockdownField(botID, chat.String())
+	wasAnnounce := false
+	if rdb != nil {
+		prev, _ := rdb.HGet(ctx, "lockdown_prev", field).Result()
+		wasAnnounce = prev == "1"
+	}
+	if !wasAnnounce {
+		if err := client.SetGroupAnnounce(context.Background(), chat, false); err != nil {
+			return false, err
+		}
+	}
+	if rdb != nil {
+		rdb.HDel(ctx, "lockdowns", field)
+		rdb.HDel(ctx, "lockdown_prev", field)
+	}
+	return !wasAnnounce, nil
+}
+
+// ⏱️ کول ڈاؤن ختم ہونے پر گروپ خود کھولیں
+func startLockdownWatcher() {
+	ticker := time.NewTicker(30 * time.Second)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			vals, err := rdb.HGetAll(ctx, "lockdowns").Result()
+			if err != nil {
+				continue
+			}
+			now := time.Now().Unix()
+			for field, val := range vals {
+				expiry, _ := strconv.ParseInt(val, 10, 64)
+				if expiry > now {
+					continue
+				}
+				parts := strings.SplitN(field, "|", 2)
+				if len(parts) != 2 {
+					rdb.HDel(ctx, "lockdowns", field)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[parts[0]]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue // بوٹ آن لائن آنے پر دوبارہ کوشش
+				}
+
+				chat, ok := parseJID(parts[1])
+				if !ok {
+					rdb.HDel(ctx, "lockdowns", field)
+					continue
+				}
+				reopened, err := endLockdown(botClient, chat)
+				if err != nil {
+					fmt.Printf("⚠️ [LOCKDOWN] Auto unlock failed for %s: %v\n", chat.User, err)
+					continue
+				}
+				msg := `╔════════════════╗
+║ 🔓 LOCKDOWN LIFTED
+╠════════════════╣
+║ ⏱️ Cooldown over
+║ All members
+║ can send now
+╚════════════════╝`
+				if !reopened {
+					msg = `╔════════════════╗
+║ 🔓 LOCKDOWN LIFTED
+╠════════════════╣
+║ ⏱️ Cooldown over
+║ 📢 Admin-only mode
+║ kept as before
+╚════════════════╝`
+				}
+				sendMentionText(botClient, chat, msg, nil)
+			}
+		}
+	}()
+}
+
+// ==================== کمانڈز ====================
+
+func handleLockdown(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	_, _, cooldown := raidConfig(s)
+
+	arg := ""
+	if len(args) > 0 {
+		arg = strings.ToLower(args[0])
+	}
+
+	switch arg {
+	case "on":
+		if len(args) > 1 {
+			if d, ok := parseDurationArg(args[1]); ok {
+				cooldown = d
+			}
+		}
+		if err := startLockdown(client, v.Info.Chat, cooldown, "Manual"); err != nil {
+			replyMessage(client, v, "⚠️ Failed to lock group (Give me Admin Rights)")
+			return
+		}
+		replyMessage(client, v, fmt.Sprintf("🔒 *Lockdown ON* — auto unlock in %s", formatDuration(cooldown)))
+	case "off":
+		// لاک ڈاؤن نہیں تو گروپ کو ہاتھ نہ لگائیں (.group close سے بند گروپ بند ہی رہے)
+		if !isLockedDown(botID, v.Info.Chat.String()) {
+			replyMessage(client, v, "🔓 This group is not in lockdown.")
+			return
+		}
+		reopened, err := endLockdown(client, v.Info.Chat)
+		if err != nil {
+			replyMessage(client, v, "⚠️ Failed to unlock group (Give me Admin Rights)")
+			return
+		}
+		logModAction(client, v.Info.Chat, "unlock", "Manual command", v.Info.Sender, types.EmptyJID, "")
+		if !reopened {
+			replyMessage(client, v, "🔓 *Lockdown OFF* — group stays admin-only as it was before")
+			return
+		}
+		replyMessage(client, v, "🔓 *Lockdown OFF* — all members can send now")
+	default:
+		status := "🔓 Not active"
+		if isLockedDown(botID, v.Info.Chat.String()) {
+			status = "🔒 Active"
+		}
+		replyMessage(client, v, fmt.Sprintf("🚨 *Lockdown:* %s\n⚠️ Usage: .lockdown on [30m] | off", status))
+	}
+}
+
+func handleAntiRaid(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 0 {
+		threshold, window, cooldown := raidConfig(s)
+		onOff := func(b bool) string {
+			if b {
+				return "✅ YES"
+			}
+			return "❌ NO"
+		}
+		status := "🔴 DISABLED"
+		if s.AntiRaid {
+			status = "🟢 ENABLED"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🚨 ANTI-RAID STATUS
+╠════════════════╣
+║ Status: %s
+║ Trigger: %d joins / %s
+║ Cooldown: %s
+║ Revoke Link: %s
+║ Kick Burst: %s
+╠════════════════╣
+║ .antiraid on/off
+║ .antiraid setup
+║ .antiraid threshold 15
+║ .antiraid window 60
+║ .antiraid cooldown 30
+║ .antiraid revoke on/off
+║ .antiraid kick on/off
+╚════════════════╝`, status, threshold, formatDuration(window), formatDuration(cooldown), onOff(s.RaidRevokeLink), onOff(s.RaidKick))
+		replyMessage(client, v, msg)
+		return
+	}
+
+	opt := strings.ToLower(args[0])
+	val := ""
+	if len(args) > 1 {
+		val = strings.ToLower(args[1])
+	}
+	n, _ := strconv.Atoi(val)
+
+	switch opt {
+	case "setup":
+		startWizard(client, v, "antiraid", botID, v.Info.Chat.String())
+		return
+	case "on":
+		s.AntiRaid = true
+	case "off":
+		s.AntiRaid = false
+	case "threshold":
+		if n < 3 {
+			replyMessage(client, v, "⚠️ Usage: .antiraid threshold <joins, min 3>")
+			return
+		}
+		s.RaidThreshold = n
+	case "window":
+		if n < 10 {
+			replyMessage(client, v, "⚠️ Usage: .antiraid window <seconds, min 10>")
+			return
+		}
+		s.RaidWindow = n
+	case "cooldown":
+		if n < 1 {
+			replyMessage(client, v, "⚠️ Usage: .antiraid cooldown <minutes>")
+			return
+		}
+		s.RaidCooldown = n
+	case "revoke", "kick":
+		if val != "on" && val != "off" {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .antiraid %s on | off", opt))
+			return
+		}
+		if opt == "revoke" {
+			s.RaidRevokeLink = val == "on"
+		} else {
+			s.RaidKick = val == "on"
+		}
+	default:
+		replyMessage(client, v, "⚠️ Invalid option. Use .antiraid to see help.")
+		return
+	}
+
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, "✅ Anti-Raid settings updated.")
+}
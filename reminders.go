@@ -0,0 +1,584 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// ⏰ REMINDERS & SCHEDULED MESSAGES
+// ════════════════════════════════════════════════════════════════
+// Data: Redis Hash "reminders" (ID -> JSON)
+// Chat: Redis Set "reminders:<chatID>" (اس چیٹ کی IDs)
+// Due:  Redis Sorted Set "reminder_due" (score = اگلا unix، member = ID)
+// دونوں Redis میں ہیں اس لیے ری اسٹارٹ کے بعد لوپ وہیں سے چلتا ہے۔
+// ٹائم زون: اسی چیٹ کی GroupSettings.Timezone (DM میں بھی)، ڈیفالٹ Asia/Karachi
+// ڈیلیوری صرف اسی بوٹ سے جس نے ریمائنڈر بنایا (BotID)۔
+
+type Reminder struct {
+	ID      string    `json:"id"`
+	BotID   string    `json:"bot_id"`
+	ChatID  string    `json:"chat_id"`
+	Creator string    `json:"creator"`
+	Text    string    `json:"text"`
+	Kind    string    `json:"kind"` // once / daily / weekly / cron
+	Clock   string    `json:"clock,omitempty"`
+	Days    []int     `json:"days,omitempty"`
+	Cron    string    `json:"cron,omitempty"`
+	Next    time.Time `json:"next"`
+	Created time.Time `json:"created"`
+}
+
+const (
+	reminderTick    = 20 * time.Second
+	reminderLateMax = time.Hour // ری اسٹارٹ کے بعد اس سے پرانا recurring میسج چھوڑ دیں
+	reminderListMax = 30
+	reminderPerUser = 10 // ہر چیٹ میں فی بنانے والا
+	cronSearchLimit = 366 * 24 * 60
+)
+
+func getReminder(id string) (*Reminder, bool) {
+	val, err := rdb.HGet(ctx, "reminders", id).Result()
+	if err != nil {
+		return nil, false
+	}
+	var r Reminder
+	if json.Unmarshal([]byte(val), &r) != nil {
+		return nil, false
+	}
+	return &r, true
+}
+
+func saveReminder(r *Reminder) {
+	jsonData, _ := json.Marshal(r)
+	rdb.HSet(ctx, "reminders", r.ID, jsonData)
+	rdb.SAdd(ctx, "reminders:"+r.ChatID, r.ID)
+	rdb.ZAdd(ctx, "reminder_due", redis.Z{Score: float64(r.Next.Unix()), Member: r.ID})
+}
+
+func deleteReminder(r *Reminder) {
+	rdb.HDel(ctx, "reminders", r.ID)
+	rdb.ZRem(ctx, "reminder_due", r.ID)
+	rdb.SRem(ctx, "reminders:"+r.ChatID, r.ID)
+}
+
+func chatReminders(chatID string) []*Reminder {
+	var list []*Reminder
+	ids, _ := rdb.SMembers(ctx, "reminders:"+chatID).Result()
+	for _, id := range ids {
+		r, ok := getReminder(id)
+		if !ok {
+			rdb.SRem(ctx, "reminders:"+chatID, id)
+			continue
+		}
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Next.Before(list[j].Next) })
+	return list
+}
+
+func reminderLocation(botID, chatID string) *time.Location {
+	return scheduleLocation(getGroupSettings(botID, chatID))
+}
+
+// ==================== کرون ====================
+
+// "m h dom mon dow" — * , - / سپورٹڈ
+type cronSpec struct {
+	minute, hour, dom, month, dow map[int]bool
+	domAny, dowAny                bool
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, s, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("bad step: %s", part)
+			}
+			step, part = n, base
+		}
+		lo, hi := min, max
+		if part != "*" {
+			from, to, isRange := strings.Cut(part, "-")
+			a, err := strconv.Atoi(from)
+			if err != nil {
+				return nil, fmt.Errorf("bad value: %s", part)
+			}
+			lo, hi = a, a
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("bad range: %s", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("out of range: %s", part)
+		}
+		for i := lo; i <= hi; i += step {
+			set[i] = true
+		}
+	}
+	return set, nil
+}
+
+func parseCron(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron needs 5 fields (min hour day month weekday)")
+	}
+	limits := [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	sets := make([]map[int]bool, 5)
+	for i, f := range fields {
+		set, err := parseCronField(f, limits[i][0], limits[i][1])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	if sets[4][7] {
+		sets[4][0] = true // 7 = اتوار
+	}
+	return &cronSpec{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}, nil
+}
+
+func (c *cronSpec) matches(t time.Time) bool {
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+	domOK, dowOK := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	// عام کرون قاعدہ: دونوں مخصوص ہوں تو کوئی ایک کافی
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowOK
+	case c.dowAny:
+		return domOK
+	}
+	return domOK || dowOK
+}
+
+func (c *cronSpec) next(after time.Time) (time.Time, bool) {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	for i := 0; i < cronSearchLimit; i++ {
+		if c.matches(t) {
+			return t, true
+		}
+		t = t.Add(time.Minute)
+	}
+	return time.Time{}, false
+}
+
+// ==================== اگلا وقت ====================
+
+func (r *Reminder) nextAfter(after time.Time, loc *time.Location) (time.Time, bool) {
+	after = after.In(loc)
+	switch r.Kind {
+	case "daily", "weekly":
+		h, m, ok := parseClock(r.Clock)
+		if !ok {
+			return time.Time{}, false
+		}
+		rule := GroupSchedule{Time: r.Clock, Days: r.Days}
+		for i := 0; i <= 7; i++ {
+			day := after.AddDate(0, 0, i)
+			at := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, loc)
+			if at.After(after) && rule.runsOn(at.Weekday()) {
+				return at, true
+			}
+		}
+	case "cron":
+		spec, err := parseCron(r.Cron)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return spec.next(after)
+	}
+	return time.Time{}, false
+}
+
+func (r *Reminder) describe() string {
+	switch r.Kind {
+	case "daily":
+		return "Daily " + r.Clock
+	case "weekly":
+		return formatScheduleDays(r.Days) + " " + r.Clock
+	case "cron":
+		return "Cron " + r.Cron
+	}
+	return "Once"
+}
+
+// ==================== لوپ ====================
+
+func startReminderWatcher() {
+	ticker := time.NewTicker(reminderTick)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			now := time.Now()
+			ids, err := rdb.ZRangeByScore(ctx, "reminder_due", &redis.ZRangeBy{Min: "-inf", Max: strconv.FormatInt(now.Unix(), 10)}).Result()
+			if err != nil {
+				continue
+			}
+			for _, id := range ids {
+				r, ok := getReminder(id)
+				if !ok {
+					rdb.ZRem(ctx, "reminder_due", id)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[r.BotID]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue // وہی بوٹ آن لائن آئے تو بھیجیں گے
+				}
+
+				late := now.Sub(r.Next)
+				if r.Kind == "once" || late < reminderLateMax {
+					deliverReminder(botClient, r, late)
+				} else {
+					fmt.Printf("⏭️ [REMIND] Skipped stale run of %s (%s late)\n", r.ID, formatDuration(late))
+				}
+
+				if r.Kind == "once" {
+					deleteReminder(r)
+					continue
+				}
+				next, ok := r.nextAfter(now, reminderLocation(r.BotID, r.ChatID))
+				if !ok {
+					deleteReminder(r)
+					continue
+				}
+				r.Next = next
+				saveReminder(r)
+			}
+		}
+	}()
+}
+
+func deliverReminder(client *whatsmeow.Client, r *Reminder, late time.Duration) {
+	chat, ok := parseJID(r.ChatID)
+	if !ok {
+		return
+	}
+	if r.Kind != "once" {
+		client.SendMessage(context.Background(), chat, buildMentionText(r.Text, nil))
+		return
+	}
+
+	creator, err := types.ParseJID(r.Creator)
+	if err != nil {
+		return
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ⏰ REMINDER
+╠════════════════╣
+║ 👤 @%s
+╚════════════════╝
+
+%s`, creator.User, r.Text)
+	if late > 2*reminderTick {
+		msg += "\n\n⌛ Delivered " + formatDuration(late) + " late"
+	}
+	sendMentionText(client, chat, msg, []string{creator.String()})
+}
+
+// ==================== کمانڈز ====================
+
+func newReminder(client *whatsmeow.Client, v *events.Message, kind, text string) *Reminder {
+	return &Reminder{
+		ID:      strconv.FormatInt(time.Now().UnixNano(), 36),
+		BotID:   getCleanID(client.Store.ID.User),
+		ChatID:  v.Info.Chat.String(),
+		Creator: v.Info.Sender.ToNonAD().String(),
+		Text:    text,
+		Kind:    kind,
+		Created: time.Now(),
+	}
+}
+
+// بنانے والا LID ہو یا نمبر، دونوں سے میچ
+func reminderOwnedBy(client *whatsmeow.Client, r *Reminder, user types.JID) bool {
+	creator, err := types.ParseJID(r.Creator)
+	if err != nil {
+		return false
+	}
+	for _, alias := range getUserAliases(client, user) {
+		if alias == getCleanID(creator.User) {
+			return true
+		}
+	}
+	return false
+}
+
+// حد ہر بنانے والے کی الگ، تاکہ ایک ممبر باقیوں (اور ایڈمن کے .schedulemsg) کی جگہ نہ بھر دے
+func reminderLimitReached(client *whatsmeow.Client, v *events.Message) bool {
+	n := 0
+	for _, r := range chatReminders(v.Info.Chat.String()) {
+		if reminderOwnedBy(client, r, v.Info.Sender) {
+			n++
+		}
+	}
+	if n >= reminderPerUser {
+		replyMessage(client, v, fmt.Sprintf("❌ You already have %d reminders in this chat. Cancel some with .reminders cancel <n>", reminderPerUser))
+		return true
+	}
+	return false
+}
+
+// .remind 2h take medicine | .remind 18:30 meeting
+func handleRemind(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	text := commandText(v)
+	if len(args) < 2 {
+		replyMessage(client, v, "⚠️ Usage: .remind <30m | 2h | 1d | 18:30> <text>")
+		return
+	}
+	loc := reminderLocation(getCleanID(client.Store.ID.User), v.Info.Chat.String())
+	at, ok := parseAnnounceTime(args[0], loc)
+	if !ok {
+		replyMessage(client, v, "❌ Invalid time. Use 30m, 2h, 1d or HH:MM")
+		return
+	}
+	if reminderLimitReached(client, v) {
+		return
+	}
+
+	r := newReminder(client, v, "once", strings.TrimSpace(text[len(args[0]):]))
+	r.Next = at
+	saveReminder(r)
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ⏰ REMINDER SET
+╠════════════════╣
+║ 📅 %s
+║ ⏳ In %s
+║ 📝 %s
+╚════════════════╝`, at.In(loc).Format("Mon 02 Jan 15:04"), formatDuration(time.Until(at)), truncateText(r.Text, 60))
+	replyMessage(client, v, msg)
+}
+
+// .schedulemsg daily 08:00 <text> | weekly mon,fri 09:00 <text> | cron "0 9 * * 1-5" <text>
+func handleScheduleMsg(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	usage := `╔════════════════╗
+║ 🗓️ SCHEDULED MESSAGES
+╠════════════════╣
+║ .schedulemsg daily 08:00 <text>
+║ .schedulemsg weekly mon,fri 09:00 <text>
+║ .schedulemsg cron "0 9 * * 1-5" <text>
+║ .reminders (list / cancel)
+╚════════════════╝`
+	if len(args) < 3 {
+		replyMessage(client, v, usage)
+		return
+	}
+
+	text := commandText(v)
+	kind := strings.ToLower(args[0])
+	text = strings.TrimSpace(text[len(args[0]):])
+	r := newReminder(client, v, kind, "")
+
+	switch kind {
+	case "daily":
+		if _, _, ok := parseClock(args[1]); !ok {
+			replyMessage(client, v, "❌ Invalid time. Use HH:MM (24h)")
+			return
+		}
+		r.Clock = args[1]
+		text = strings.TrimSpace(text[len(args[1]):])
+
+	case "weekly":
+		if len(args) < 4 {
+			replyMessage(client, v, usage)
+			return
+		}
+		days, err := parseScheduleDays(args[1])
+		if err != nil {
+			replyMessage(client, v, "❌ "+err.Error())
+			return
+		}
+		if _, _, ok := parseClock(args[2]); !ok {
+			replyMessage(client, v, "❌ Invalid time. Use HH:MM (24h)")
+			return
+		}
+		r.Days, r.Clock = days, args[2]
+		text = strings.TrimSpace(text[len(args[1]):])
+		text = strings.TrimSpace(text[len(args[2]):])
+
+	case "cron":
+		// کوٹس میں ہو تو پورا، ورنہ پہلے پانچ الفاظ
+		expr := ""
+		if strings.HasPrefix(text, `"`) {
+			end := strings.Index(text[1:], `"`)
+			if end < 0 {
+				replyMessage(client, v, usage)
+				return
+			}
+			expr, text = text[1:end+1], text[end+2:]
+		} else {
+			fields := strings.Fields(text)
+			if len(fields) < 6 {
+				replyMessage(client, v, usage)
+				return
+			}
+			expr = strings.Join(fields[:5], " ")
+			for _, f := range fields[:5] {
+				text = strings.TrimSpace(text)[len(f):]
+			}
+		}
+		if _, err := parseCron(expr); err != nil {
+			replyMessage(client, v, "❌ "+err.Error())
+			return
+		}
+		r.Cron = strings.Join(strings.Fields(expr), " ")
+
+	default:
+		replyMessage(client, v, usage)
+		return
+	}
+
+	r.Text = strings.TrimSpace(text)
+	if r.Text == "" {
+		replyMessage(client, v, "⚠️ Please add the message text.")
+		return
+	}
+	loc := reminderLocation(r.BotID, r.ChatID)
+	next, ok := r.nextAfter(time.Now(), loc)
+	if !ok {
+		replyMessage(client, v, "❌ This schedule never runs.")
+		return
+	}
+	if reminderLimitReached(client, v) {
+		return
+	}
+	r.Next = next
+	saveReminder(r)
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🗓️ MESSAGE SCHEDULED
+╠════════════════╣
+║ 🔁 %s
+║ ⏭️ Next: %s
+║ 🌍 %s
+║ 📝 %s
+╚════════════════╝`, r.describe(), next.Format("Mon 02 Jan 15:04"), loc.String(), truncateText(r.Text, 60))
+	replyMessage(client, v, msg)
+}
+
+// .reminders | .reminders cancel <n> | .reminders tz <Area/City>
+func handleReminders(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "list":
+		loc := reminderLocation(botID, chatID)
+		list := chatReminders(chatID)
+		out := "╔════════════════╗\n"
+		out += "║ ⏰ REMINDERS\n"
+		out += "║ 🌍 " + loc.String() + "\n"
+		out += "╠════════════════╣\n"
+		if len(list) == 0 {
+			out += "║ 📭 Nothing scheduled\n"
+		}
+		for i, r := range list {
+			if i >= reminderListMax {
+				out += fmt.Sprintf("║ ... and %d more\n", len(list)-reminderListMax)
+				break
+			}
+			out += fmt.Sprintf("║ %d. %s • %s\n║    %s\n", i+1, r.Next.In(loc).Format("02 Jan 15:04"), r.describe(), truncateText(r.Text, 40))
+		}
+		out += "╠════════════════╣\n"
+		out += "║ .reminders cancel <n>\n"
+		out += "║ .reminders tz <Area/City>\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	case "cancel", "del", "delete":
+		list := chatReminders(chatID)
+		n := 0
+		if len(args) > 1 {
+			n, _ = strconv.Atoi(args[1])
+		}
+		if n < 1 || n > len(list) {
+			replyMessage(client, v, "⚠️ Usage: .reminders cancel <number> (see .reminders)")
+			return
+		}
+		r := list[n-1]
+		// اپنا ریمائنڈر ہر کوئی، باقی صرف ایڈمن
+		if !reminderOwnedBy(client, r, v.Info.Sender) && !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		deleteReminder(r)
+		replyMessage(client, v, fmt.Sprintf("🗑️ Reminder #%d cancelled.", n))
+
+	case "tz", "timezone":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .reminders tz Asia/Karachi")
+			return
+		}
+		if v.Info.IsGroup && !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		loc, err := time.LoadLocation(args[1])
+		if err != nil {
+			replyMessage(client, v, "❌ Unknown timezone: "+args[1])
+			return
+		}
+		s := getGroupSettings(botID, chatID)
+		s.Timezone = loc.String()
+		saveGroupSettings(botID, s)
+
+		// recurring ریمائنڈرز نئے زون میں دوبارہ حساب
+		for _, r := range chatReminders(chatID) {
+			if r.Kind == "once" {
+				continue
+			}
+			if next, ok := r.nextAfter(time.Now(), loc); ok {
+				r.Next = next
+				saveReminder(r)
+			}
+		}
+		replyMessage(client, v, "✅ Timezone set to "+loc.String())
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .reminders | .reminders cancel <n> | .reminders tz <Area/City>")
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 👑 ROLE SYSTEM
+// ════════════════════════════════════════════════════════════════
+// Owner > Sudo > Group Admin > Trusted > Member > Banned
+// Sudo:    Redis Set "sudo:<botID>"     (فی بوٹ، اونر لیول یوزرز)
+// Trusted: Redis Set "trusted:<chatID>" (گروپ کی آٹو ماڈریشن سے مستثنیٰ)
+
+type Role int
+
+const (
+	RoleBanned Role = iota
+	RoleMember
+	RoleTrusted
+	RoleAdmin
+	RoleSudo
+	RoleOwner
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleOwner:
+		return "Owner"
+	case RoleSudo:
+		return "Sudo"
+	case RoleAdmin:
+		return "Admin"
+	case RoleTrusted:
+		return "Trusted"
+	case RoleBanned:
+		return "Banned"
+	}
+	return "Member"
+}
+
+// ⚙️ ہر کمانڈ کے لیے کم از کم رول (جو یہاں نہیں وہ سب کے لیے کھلی ہے)
+// ban / unban / banlist / antibug اپنے اندر چیک کرتے ہیں (global / DM پر رول بدلتا ہے)
+var commandRoles = map[string]Role{
+	// 👑 Owner
+	"sudo": RoleOwner, "sd": RoleOwner,
+
+	// 🛡️ Owner + Sudo
+	"setprefix": RoleSudo, "mode": RoleSudo, "send": RoleSudo,
+	"alwaysonline": RoleSudo, "autoread": RoleSudo, "autoreact": RoleSudo,
+	"autostatus": RoleSudo, "statusreact": RoleSudo, "addstatus": RoleSudo,
+	"delstatus": RoleSudo, "liststatus": RoleSudo, "readallstatus": RoleSudo,
+	"anticall": RoleSudo, "lock": RoleSudo, "unlock": RoleSudo, "restore": RoleSudo, "guard": RoleSudo,
+
+	// 👮 Group Admin
+	"welcome": RoleAdmin, "wel": RoleAdmin,
+	"kick": RoleAdmin, "add": RoleAdmin, "promote": RoleAdmin, "demote": RoleAdmin,
+	"tagall": RoleAdmin, "hidetag": RoleAdmin, "group": RoleAdmin, "del": RoleAdmin, "delete": RoleAdmin,
+	"mute": RoleAdmin, "unmute": RoleAdmin, "mutes": RoleAdmin, "captcha": RoleAdmin,
+	"antiraid": RoleAdmin, "lockdown": RoleAdmin, "modlog": RoleAdmin, "trust": RoleAdmin, "requests": RoleAdmin, "schedule": RoleAdmin,
+	"inactive": RoleAdmin, "purge": RoleAdmin,
+	"setwelcome": RoleAdmin, "setgoodbye": RoleAdmin, "setpromote": RoleAdmin, "setdemote": RoleAdmin, "testwelcome": RoleAdmin,
+	"setrules": RoleAdmin, "snapshot": RoleAdmin, "announce": RoleAdmin, "xp": RoleAdmin, "poll": RoleAdmin, "schedulemsg": RoleAdmin,
+	"antilink": RoleAdmin, "antipic": RoleAdmin, "antivideo": RoleAdmin, "antisticker": RoleAdmin,
+	"antidelete": RoleAdmin, "antimedia": RoleAdmin, "antinsfw": RoleAdmin,
+}
+
+// ==================== اسٹوریج ====================
+
+func isSudo(client *whatsmeow.Client, user types.JID) bool {
+	if rdb == nil {
+		return false
+	}
+	key := "sudo:" + getCleanID(client.Store.ID.User)
+	for _, alias := range getUserAliases(client, user) {
+		if ok, _ := rdb.SIsMember(ctx, key, alias).Result(); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isTrusted(client *whatsmeow.Client, chat, user types.JID) bool {
+	if rdb == nil {
+		return false
+	}
+	for _, alias := range getUserAliases(client, user) {
+		if ok, _ := rdb.SIsMember(ctx, "trusted:"+chat.String(), alias).Result(); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// 🎭 یوزر کا رول (گروپ میں ایڈمن/ٹرسٹڈ/بین بھی دیکھا جاتا ہے)
+func getUserRole(client *whatsmeow.Client, chat, user types.JID) Role {
+	if isOwner(client, user) || getCleanID(user.User) == getCleanID(client.Store.ID.User) {
+		return RoleOwner
+	}
+	if isSudo(client, user) {
+		return RoleSudo
+	}
+
+	chatID := ""
+	if chat.Server == types.GroupServer {
+		chatID = chat.String()
+	}
+	if findBan(client, chatID, user) != nil {
+		return RoleBanned
+	}
+	if chatID == "" {
+		return RoleMember
+	}
+	if isAdmin(client, chat, user) {
+		return RoleAdmin
+	}
+	if isTrusted(client, chat, user) {
+		return RoleTrusted
+	}
+	return RoleMember
+}
+
+// ❌ ایک ہی انکار کارڈ، ہر کمانڈ کے لیے
+func sendDenied(client *whatsmeow.Client, v *events.Message, need Role) {
+	label := need.String() + " Only"
+	if need == RoleSudo {
+		label = "Owner / Sudo Only"
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ❌ ACCESS DENIED
+╠════════════════╣
+║ 🔒 %s
+╚════════════════╝`, label)
+	replyMessage(client, v, msg)
+}
+
+// رول کم ہو تو انکار کارڈ بھیج کر false واپس کرتا ہے
+func requireRole(client *whatsmeow.Client, v *events.Message, need Role) bool {
+	if getUserRole(client, v.Info.Chat, v.Info.Sender) >= need {
+		return true
+	}
+	sendDenied(client, v, need)
+	return false
+}
+
+// کمانڈ ٹیبل کے مطابق چیک (dispatcher سے، رول وہیں ایک بار نکلتا ہے)
+func checkCommandRole(client *whatsmeow.Client, v *events.Message, cmd string, role Role) bool {
+	need, ok := commandRoles[cmd]
+	if !ok || role >= need {
+		return true
+	}
+	sendDenied(client, v, need)
+	return false
+}
+
+// ==================== کمانڈز ====================
+
+// .sudo add/del @user | .sudo list
+func handleSudo(client *whatsmeow.Client, v *events.Message, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	key := "sudo:" + botID
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+
+	switch sub {
+	case "add", "del":
+		target, _ := resolveTarget(v, args)
+		if target.User == "" {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .sudo %s @user|number", sub))
+			return
+		}
+		aliases := getUserAliases(client, target)
+		if sub == "add" {
+			rdb.SAdd(ctx, key, aliases)
+		} else {
+			rdb.SRem(ctx, key, aliases)
+		}
+
+		title := "👑 SUDO ADDED"
+		if sub == "del" {
+			title = "🗑️ SUDO REMOVED"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ %s
+╠════════════════╣
+║ 👤 User: @%s
+║ 🤖 Bot: %s
+╚════════════════╝`, title, target.User, botID)
+		sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+
+	case "list", "":
+		members, _ := rdb.SMembers(ctx, key).Result()
+		if len(members) == 0 {
+			replyMessage(client, v, "📭 No sudo users. Use .sudo add @user")
+			return
+		}
+		sort.Strings(members)
+		out := "╔════════════════╗\n"
+		out += "║ 👑 SUDO USERS\n"
+		out += "╠════════════════╣\n"
+		for i, m := range members {
+			out += fmt.Sprintf("║ %d. %s\n", i+1, m)
+		}
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .sudo add|del @user | .sudo list")
+	}
+}
+
+// .trust add/del @user | .trust list (گروپ)
+func handleTrust(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	key := "trusted:" + v.Info.Chat.String()
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	switch sub {
+	case "add", "del":
+		target, _ := resolveTarget(v, args)
+		if target.User == "" {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .trust %s @user|number", sub))
+			return
+		}
+		aliases := getUserAliases(client, target)
+		status := "✅ Trusted (skips auto-moderation)"
+		if sub == "add" {
+			rdb.SAdd(ctx, key, aliases)
+		} else {
+			rdb.SRem(ctx, key, aliases)
+			status = "❌ No longer trusted"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🤝 TRUST
+╠════════════════╣
+║ 👤 User: @%s
+║ %s
+╚════════════════╝`, target.User, status)
+		sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+
+	case "list", "":
+		members, _ := rdb.SMembers(ctx, key).Result()
+		if len(members) == 0 {
+			replyMessage(client, v, "📭 No trusted members. Use .trust add @user")
+			return
+		}
+		sort.Strings(members)
+		out := "╔════════════════╗\n"
+		out += "║ 🤝 TRUSTED MEMBERS\n"
+		out += "╠════════════════╣\n"
+		for i, m := range members {
+			out += fmt.Sprintf("║ %d. %s\n", i+1, m)
+		}
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .trust add|del @user | .trust list")
+	}
+}
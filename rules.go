@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📜 GROUP RULES & ACKNOWLEDGEMENT
+// ════════════════════════════════════════════════════════════════
+// رولز GroupSettings.Rules میں۔ بھیجے گئے رولز میسجز کی ID
+// Redis "rules_msg:<msgID>" (-> chatID) میں رکھی جاتی ہے؛ اس پر ری ایکٹ
+// یا ریپلائی کرنے والا Redis Set "rules_ack:<chatID>" میں چلا جاتا ہے۔
+// رولز بدلنے پر پرانی منظوریاں ختم ہو جاتی ہیں۔
+
+const (
+	rulesMsgTTL     = 30 * 24 * time.Hour
+	rulesPendingMax = 50
+)
+
+func rulesAckKey(chatID string) string {
+	return "rules_ack:" + chatID
+}
+
+func formatRules(groupName, rules string) string {
+	return fmt.Sprintf(`╔════════════════╗
+║ 📜 GROUP RULES
+╠════════════════╣
+║ 👥 %s
+╚════════════════╝
+
+%s
+
+✅ React or reply to this message to accept the rules.`, groupName, rules)
+}
+
+// رولز بھیجیں اور میسج ID ٹریکنگ کے لیے محفوظ کریں
+func sendRules(client *whatsmeow.Client, to, group types.JID, s *GroupSettings, mentions []string) error {
+	groupName := group.User
+	if info, err := client.GetGroupInfo(context.Background(), group); err == nil {
+		groupName = info.Name
+	}
+	text := formatRules(groupName, s.Rules)
+	if len(mentions) > 0 {
+		var tags []string
+		for _, m := range mentions {
+			if jid, err := types.ParseJID(m); err == nil {
+				tags = append(tags, "@"+jid.User)
+			}
+		}
+		text = strings.Join(tags, " ") + "\n" + text
+	}
+
+	resp, err := client.SendMessage(context.Background(), to, buildMentionText(text, mentions))
+	if err != nil {
+		return err
+	}
+	if rdb != nil {
+		rdb.Set(ctx, "rules_msg:"+resp.ID, group.String(), rulesMsgTTL)
+	}
+	return nil
+}
+
+// handleGroupInfoChange سے: نئے ممبرز کو رولز (گروپ یا DM میں)
+func sendRulesToNewcomers(client *whatsmeow.Client, s *GroupSettings, chat types.JID, joined []types.JID) {
+	if s.Rules == "" || s.RulesOnJoin == "" || len(joined) == 0 {
+		return
+	}
+	if s.RulesOnJoin == "dm" {
+		for _, u := range joined {
+			if err := sendRules(client, u, chat, s, nil); err != nil {
+				fmt.Printf("⚠️ [RULES] DM to %s failed: %v\n", u.User, err)
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		return
+	}
+	var mentions []string
+	for _, u := range joined {
+		mentions = append(mentions, u.String())
+	}
+	sendRules(client, chat, chat, s, mentions)
+}
+
+// ری ایکشن یا ریپلائی سے منظوری (میسج آگے پروسیس ہوتا رہتا ہے)
+func trackRulesAck(client *whatsmeow.Client, v *events.Message) {
+	if rdb == nil || v.Info.IsFromMe {
+		return
+	}
+	targetID := ""
+	if r := v.Message.GetReactionMessage(); r != nil {
+		if r.GetText() == "" {
+			return // ری ایکشن ہٹایا گیا
+		}
+		targetID = r.GetKey().GetID()
+	} else if ci := messageContextInfo(v.Message); ci != nil {
+		targetID = ci.GetStanzaID()
+	}
+	if targetID == "" {
+		return
+	}
+
+	chatID, err := rdb.Get(ctx, "rules_msg:"+targetID).Result()
+	if err != nil {
+		return
+	}
+	aliases := getUserAliases(client, v.Info.Sender)
+	if n, _ := rdb.SAdd(ctx, rulesAckKey(chatID), aliases).Result(); n > 0 {
+		fmt.Printf("📜 [RULES] %s accepted rules of %s\n", v.Info.Sender.User, chatID)
+	}
+}
+
+func hasAcceptedRules(chatID string, p types.GroupParticipant) bool {
+	for _, id := range []types.JID{p.JID, p.PhoneNumber, p.LID} {
+		if id.User == "" {
+			continue
+		}
+		if ok, _ := rdb.SIsMember(ctx, rulesAckKey(chatID), getCleanID(id.User)).Result(); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== کمانڈز ====================
+
+// .rules | .rules pending | .rules onjoin group/dm/off | .rules reset
+func handleRules(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	s := getGroupSettings(botID, chat.String())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// ایڈمن سب کمانڈز
+	if sub != "" && !requireRole(client, v, RoleAdmin) {
+		return
+	}
+
+	switch sub {
+	case "":
+		if s.Rules == "" {
+			replyMessage(client, v, "📭 No rules set. Admins can use .setrules <text>")
+			return
+		}
+		sendRules(client, chat, chat, s, nil)
+
+	case "pending":
+		if rdb == nil {
+			replyMessage(client, v, "❌ Database not connected.")
+			return
+		}
+		info, err := client.GetGroupInfo(context.Background(), chat)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not fetch group info.")
+			return
+		}
+		var pending []types.JID
+		accepted := 0
+		for _, p := range info.Participants {
+			if participantIsAdmin(p) || getCleanID(p.JID.User) == botID {
+				continue
+			}
+			if hasAcceptedRules(chat.String(), p) {
+				accepted++
+				continue
+			}
+			pending = append(pending, p.JID)
+		}
+
+		out := "╔════════════════╗\n"
+		out += "║ 📜 RULES PENDING\n"
+		out += "╠════════════════╣\n"
+		if len(pending) == 0 {
+			out += "║ ✅ Everyone accepted\n"
+		}
+		var mentions []string
+		for i, u := range pending {
+			if i >= rulesPendingMax {
+				out += fmt.Sprintf("║ ... and %d more\n", len(pending)-rulesPendingMax)
+				break
+			}
+			out += fmt.Sprintf("║ %d. @%s\n", i+1, u.User)
+			mentions = append(mentions, u.String())
+		}
+		out += "╠════════════════╣\n"
+		out += fmt.Sprintf("║ ✅ Accepted: %d\n", accepted)
+		out += fmt.Sprintf("║ ⏳ Pending: %d\n", len(pending))
+		out += "╚════════════════╝"
+		sendMentionText(client, chat, out, mentions)
+
+	case "onjoin":
+		mode := ""
+		if len(args) > 1 {
+			mode = strings.ToLower(args[1])
+		}
+		switch mode {
+		case "group", "dm":
+			s.RulesOnJoin = mode
+		case "off":
+			s.RulesOnJoin = ""
+		default:
+			replyMessage(client, v, "⚠️ Usage: .rules onjoin group | dm | off")
+			return
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Rules on join: "+strings.ToUpper(mode))
+
+	case "reset":
+		if rdb != nil {
+			rdb.Del(ctx, rulesAckKey(chat.String()))
+		}
+		replyMessage(client, v, "🗑️ All acceptances cleared. Members must accept again.")
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .rules | .rules pending | .rules onjoin group/dm/off | .rules reset")
+	}
+}
+
+// .setrules <text> (ملٹی لائن) | .setrules clear
+func handleSetRules(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	text := commandText(v)
+	if text == "" {
+		replyMessage(client, v, "⚠️ Usage: .setrules <rules text> | .setrules clear")
+		return
+	}
+	if strings.ToLower(text) == "clear" {
+		text = ""
+	}
+
+	s.Rules = text
+	saveGroupSettings(botID, s)
+	if rdb != nil {
+		rdb.Del(ctx, rulesAckKey(v.Info.Chat.String()))
+	}
+
+	if text == "" {
+		replyMessage(client, v, "🗑️ Rules cleared.")
+		return
+	}
+	replyMessage(client, v, "✅ Rules updated. Previous acceptances were reset.\nUse .rules to post them.")
+}
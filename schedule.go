@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // کنٹینر میں zoneinfo نہ ہو تب بھی ٹائم زون لوڈ ہوں
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// ⏰ SCHEDULED GROUP OPEN / CLOSE
+// ════════════════════════════════════════════════════════════════
+// قوانین GroupSettings.Schedules میں، آخری لاگو ٹرانزیشن Redis Hash
+// "schedule_last" ("botID|chatID" -> unix) میں۔ ری اسٹارٹ کے بعد واچر
+// سب سے تازہ چھوٹی ہوئی ٹرانزیشن لاگو کر دیتا ہے (catch-up)۔
+// Scheduled groups: Redis Set "schedule_groups" ("botID|chatID")
+
+type GroupSchedule struct {
+	Action string `json:"action"` // open / close
+	Time   string `json:"time"`   // HH:MM
+	Days   []int  `json:"days"`   // 0=Sun..6=Sat، خالی = روزانہ
+}
+
+const (
+	defaultScheduleTZ    = "Asia/Karachi"
+	defaultCloseTemplate = "🔒 *{group}* is now closed.\nOnly admins can send messages.\n⏰ Opens: {next}"
+	defaultOpenTemplate  = "🔓 *{group}* is now open.\nAll members can send messages.\n⏰ Closes: {next}"
+	scheduleLookback     = 8 * 24 * time.Hour
+)
+
+var (
+	weekdayNames  = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	weekdayLabels = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+)
+
+func scheduleField(botID, chatID string) string {
+	return botID + "|" + chatID
+}
+
+func scheduleLocation(s *GroupSettings) *time.Location {
+	name := s.Timezone
+	if name == "" {
+		name = defaultScheduleTZ
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// "mon-fri" / "sat,sun" / "daily" / "weekdays" / "weekends"
+func parseScheduleDays(arg string) ([]int, error) {
+	arg = strings.ToLower(arg)
+	switch arg {
+	case "", "daily", "everyday":
+		return nil, nil
+	case "weekdays":
+		arg = "mon-fri"
+	case "weekends":
+		arg = "sat,sun"
+	}
+
+	dayIndex := func(name string) int {
+		for i, d := range weekdayNames {
+			if strings.HasPrefix(name, d) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	set := make(map[int]bool)
+	for _, part := range strings.Split(arg, ",") {
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			a, b := dayIndex(from), dayIndex(to)
+			if a < 0 || b < 0 {
+				return nil, fmt.Errorf("unknown day: %s", part)
+			}
+			for i := a; ; i = (i + 1) % 7 {
+				set[i] = true
+				if i == b {
+					break
+				}
+			}
+			continue
+		}
+		d := dayIndex(part)
+		if d < 0 {
+			return nil, fmt.Errorf("unknown day: %s", part)
+		}
+		set[d] = true
+	}
+
+	var days []int
+	for d := range set {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+	if len(days) == 7 {
+		return nil, nil
+	}
+	return days, nil
+}
+
+func formatScheduleDays(days []int) string {
+	if len(days) == 0 {
+		return "Daily"
+	}
+	var names []string
+	for _, d := range days {
+		names = append(names, weekdayLabels[d])
+	}
+	return strings.Join(names, ", ")
+}
+
+func parseClock(arg string) (int, int, bool) {
+	t, err := time.Parse("15:04", arg)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
+
+func (r GroupSchedule) runsOn(day time.Weekday) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	for _, d := range r.Days {
+		if d == int(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// رول کا occurrence جو [from, to] کے اندر سب سے تازہ ہو
+func (r GroupSchedule) lastBetween(from, to time.Time) (time.Time, bool) {
+	h, m, ok := parseClock(r.Time)
+	if !ok {
+		return time.Time{}, false
+	}
+	for day := to; !day.Before(from.Add(-24 * time.Hour)); day = day.AddDate(0, 0, -1) {
+		at := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, to.Location())
+		if at.After(to) || at.Before(from) || !r.runsOn(at.Weekday()) {
+			continue
+		}
+		return at, true
+	}
+	return time.Time{}, false
+}
+
+// کسی ایکشن کی اگلی ٹرانزیشن (اعلان کے {next} کے لیے)
+func nextScheduleTime(s *GroupSettings, action string, after time.Time) (time.Time, bool) {
+	var best time.Time
+	found := false
+	for _, r := range s.Schedules {
+		if r.Action != action {
+			continue
+		}
+		h, m, ok := parseClock(r.Time)
+		if !ok {
+			continue
+		}
+		for i := 0; i <= 7; i++ {
+			day := after.AddDate(0, 0, i)
+			at := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, after.Location())
+			if !at.After(after) || !r.runsOn(at.Weekday()) {
+				continue
+			}
+			if !found || at.Before(best) {
+				best, found = at, true
+			}
+			break
+		}
+	}
+	return best, found
+}
+
+func renderScheduleTemplate(tmpl, groupName string, s *GroupSettings, action string, now time.Time) string {
+	if tmpl == "" {
+		tmpl = defaultCloseTemplate
+		if action == "open" {
+			tmpl = defaultOpenTemplate
+		}
+	}
+	opposite := "open"
+	if action == "open" {
+		opposite = "close"
+	}
+	next := "Not scheduled"
+	if t, ok := nextScheduleTime(s, opposite, now); ok {
+		next = t.Format("Mon 15:04")
+	}
+	return strings.NewReplacer(
+		"{group}", groupName,
+		"{time}", now.Format("15:04"),
+		"{next}", next,
+	).Replace(tmpl)
+}
+
+// ==================== واچر ====================
+
+func applyScheduledTransition(client *whatsmeow.Client, chat types.JID, s *GroupSettings, action string, now time.Time) error {
+	// 🚨 لاک ڈاؤن میں گروپ نہ کھولیں؛ یہ حالت لاک ڈاؤن ختم ہونے پر لاگو ہوگی
+	botID := getCleanID(client.Store.ID.User)
+	if isLockedDown(botID, chat.String()) {
+		setLockdownRestoreState(botID, chat.String(), action == "close")
+		fmt.Printf("⏰ [SCHEDULE] %s deferred for %s (lockdown active)\n", action, chat.User)
+		return nil
+	}
+
+	if err := client.SetGroupAnnounce(context.Background(), chat, action == "close"); err != nil {
+		return err
+	}
+	fmt.Printf("⏰ [SCHEDULE] %s -> %s\n", chat.User, action)
+	logModAction(client, chat, "schedule", "Scheduled "+action, types.EmptyJID, types.EmptyJID, "")
+
+	groupName := chat.User
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		groupName = info.Name
+	}
+	tmpl := s.CloseMsg
+	if action == "open" {
+		tmpl = s.OpenMsg
+	}
+	if tmpl != "off" {
+		sendMentionText(client, chat, renderScheduleTemplate(tmpl, groupName, s, action, now), nil)
+	}
+	return nil
+}
+
+func checkGroupSchedule(client *whatsmeow.Client, chat types.JID, field string) {
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, chat.String())
+	if len(s.Schedules) == 0 {
+		rdb.SRem(ctx, "schedule_groups", field)
+		return
+	}
+
+	now := time.Now().In(scheduleLocation(s))
+	from := now.Add(-scheduleLookback)
+	if last, err := rdb.HGet(ctx, "schedule_last", field).Int64(); err == nil {
+		from = time.Unix(last+1, 0).In(now.Location())
+	}
+
+	// صرف سب سے تازہ چھوٹی ہوئی ٹرانزیشن اہم ہے
+	var latest time.Time
+	action := ""
+	for _, r := range s.Schedules {
+		if at, ok := r.lastBetween(from, now); ok && at.After(latest) {
+			latest, action = at, r.Action
+		}
+	}
+	if action == "" {
+		return
+	}
+
+	if err := applyScheduledTransition(client, chat, s, action, now); err != nil {
+		fmt.Printf("⚠️ [SCHEDULE] %s failed for %s: %v\n", action, chat.User, err)
+		return // اگلے ٹک پر دوبارہ کوشش
+	}
+	rdb.HSet(ctx, "schedule_last", field, latest.Unix())
+}
+
+func startScheduleWatcher() {
+	ticker := time.NewTicker(30 * time.Second)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			fields, err := rdb.SMembers(ctx, "schedule_groups").Result()
+			if err != nil {
+				continue
+			}
+			for _, field := range fields {
+				parts := strings.SplitN(field, "|", 2)
+				if len(parts) != 2 {
+					rdb.SRem(ctx, "schedule_groups", field)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[parts[0]]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue // بوٹ آن لائن آنے پر catch-up
+				}
+
+				chat, ok := parseJID(parts[1])
+				if !ok {
+					rdb.SRem(ctx, "schedule_groups", field)
+					continue
+				}
+				checkGroupSchedule(botClient, chat, field)
+			}
+		}
+	}()
+}
+
+// ==================== کمانڈ ====================
+
+func handleSchedule(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	field := scheduleField(botID, chatID)
+	s := getGroupSettings(botID, chatID)
+
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "open", "close":
+		if len(args) < 2 {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .schedule %s 23:00 [daily|mon-fri|sat,sun]", sub))
+			return
+		}
+		h, m, ok := parseClock(args[1])
+		if !ok {
+			replyMessage(client, v, "❌ Invalid time. Use 24h format like 07:00 or 23:30")
+			return
+		}
+		dayArg := ""
+		if len(args) > 2 {
+			dayArg = strings.Join(args[2:], ",")
+		}
+		days, err := parseScheduleDays(dayArg)
+		if err != nil {
+			replyMessage(client, v, "❌ "+err.Error())
+			return
+		}
+
+		s.Schedules = append(s.Schedules, GroupSchedule{
+			Action: sub,
+			Time:   fmt.Sprintf("%02d:%02d", h, m),
+			Days:   days,
+		})
+		saveGroupSettings(botID, s)
+		rdb.SAdd(ctx, "schedule_groups", field)
+		// پرانی ٹرانزیشنز دوبارہ لاگو نہ ہوں، صرف اب کے بعد والی
+		rdb.HSetNX(ctx, "schedule_last", field, time.Now().Unix())
+
+		emoji := "🔒"
+		if sub == "open" {
+			emoji = "🔓"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ⏰ SCHEDULE ADDED
+╠════════════════╣
+║ %s %s at %02d:%02d
+║ 📅 %s
+║ 🌍 %s
+╚════════════════╝`, emoji, strings.ToUpper(sub), h, m, formatScheduleDays(days), scheduleLocation(s).String())
+		replyMessage(client, v, msg)
+
+	case "del", "delete", "remove":
+		n := 0
+		if len(args) > 1 {
+			n, _ = strconv.Atoi(args[1])
+		}
+		if n < 1 || n > len(s.Schedules) {
+			replyMessage(client, v, "⚠️ Usage: .schedule del <number> (see .schedule list)")
+			return
+		}
+		s.Schedules = append(s.Schedules[:n-1], s.Schedules[n:]...)
+		saveGroupSettings(botID, s)
+		if len(s.Schedules) == 0 {
+			rdb.SRem(ctx, "schedule_groups", field)
+			rdb.HDel(ctx, "schedule_last", field)
+		}
+		replyMessage(client, v, fmt.Sprintf("🗑️ Schedule #%d removed.", n))
+
+	case "clear":
+		s.Schedules = nil
+		saveGroupSettings(botID, s)
+		rdb.SRem(ctx, "schedule_groups", field)
+		rdb.HDel(ctx, "schedule_last", field)
+		replyMessage(client, v, "🗑️ All schedules cleared.")
+
+	case "tz", "timezone":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .schedule tz Asia/Karachi")
+			return
+		}
+		loc, err := time.LoadLocation(args[1])
+		if err != nil {
+			replyMessage(client, v, "❌ Unknown timezone. Example: Asia/Karachi, Europe/London, UTC")
+			return
+		}
+		s.Timezone = loc.String()
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("🌍 Timezone set to %s (now %s)", loc.String(), time.Now().In(loc).Format("15:04")))
+
+	case "msg":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .schedule msg open|close <text> | off | reset\nVars: {group} {time} {next}")
+			return
+		}
+		which := strings.ToLower(args[1])
+		if which != "open" && which != "close" {
+			replyMessage(client, v, "⚠️ Usage: .schedule msg open|close <text> | off | reset")
+			return
+		}
+		// اصل ٹیکسٹ (لائن بریکس سمیت) کمانڈ کے بعد سے
+		text := ""
+		body := getText(v.Message)
+		if idx := strings.Index(strings.ToLower(body), " "+which); idx >= 0 {
+			text = strings.TrimSpace(body[idx+len(which)+1:])
+		}
+		if text == "" {
+			replyMessage(client, v, "⚠️ Provide the announcement text, 'off' or 'reset'.")
+			return
+		}
+		switch strings.ToLower(text) {
+		case "reset":
+			text = ""
+		case "off":
+			text = "off"
+		}
+		if which == "open" {
+			s.OpenMsg = text
+		} else {
+			s.CloseMsg = text
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %s announcement updated.", strings.ToUpper(which)))
+
+	case "list":
+		loc := scheduleLocation(s)
+		out := "╔════════════════╗\n"
+		out += "║ ⏰ GROUP SCHEDULE\n"
+		out += "╠════════════════╣\n"
+		if len(s.Schedules) == 0 {
+			out += "║ 📭 No schedules\n"
+		}
+		for i, r := range s.Schedules {
+			emoji := "🔒"
+			if r.Action == "open" {
+				emoji = "🔓"
+			}
+			out += fmt.Sprintf("║ %d. %s %s %s (%s)\n", i+1, emoji, strings.ToUpper(r.Action), r.Time, formatScheduleDays(r.Days))
+		}
+		out += "╠════════════════╣\n"
+		out += fmt.Sprintf("║ 🌍 %s (%s)\n", loc.String(), time.Now().In(loc).Format("15:04"))
+		out += "║ .schedule close 23:00 [days]\n"
+		out += "║ .schedule open 07:00 mon-fri\n"
+		out += "║ .schedule del <n> | clear\n"
+		out += "║ .schedule tz Asia/Karachi\n"
+		out += "║ .schedule msg open|close <text>\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .schedule open|close HH:MM [days] | list | del | clear | tz | msg")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 	"encoding/json"
     //"unicode"
 	"go.mau.fi/whatsmeow"
@@ -15,8 +14,6 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-var AntiBugEnabled = false
-
 // 🛡️ سیٹنگز کا ڈھانچہ (Structure)
 // اس میں تم مزید چیزیں بھی ڈال سکتے ہو جیسے AntiLink، Welcome وغیرہ
 type BotSettings struct {
@@ -103,25 +100,6 @@ func LoadGroupSecurity(rdb *redis.Client, botLID string, groupID string) GroupSe
 	return data
 }
 
-// فرض کریں یوزر نے 'antilink' آن کرنے کا فیصلہ کر لیا ہے
-func finalizeSecurity(client *whatsmeow.Client, senderLID string, choice string) {
-	state := setupMap[senderLID]
-	if state == nil { return }
-
-	allowAdmin := (choice == "1") // اگر یوزر نے 1 دبایا تو ایڈمن الاؤ ہیں
-	
-	// سیٹنگز تیار کریں
-	newConfig := GroupSecurity{
-		AntiLink:   true, // کیونکہ وہ اینٹی لنک کا سیٹ اپ کر رہا تھا
-		AllowAdmin: allowAdmin,
-	}
-
-	// 💾 ریڈیس میں اس گروپ کے لیے مخصوص سیو کریں
-	SaveGroupSecurity(rdb, state.BotLID, state.GroupID, newConfig)
-	
-	// میپ سے ڈیلیٹ کر دیں
-	delete(setupMap, senderLID)
-}
 // ==================== سیکورٹی سسٹم ====================
 func checkSecurity(client *whatsmeow.Client, v *events.Message) {
 	// ✅ 1. Bot ID نکالیں
@@ -139,6 +117,11 @@ func checkSecurity(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
+	// 🤝 ٹرسٹڈ ممبرز آٹو ماڈریشن سے مستثنیٰ
+	if isTrusted(client, v.Info.Chat, v.Info.Sender) {
+		return
+	}
+
 	// ✅ Anti-link check
 	if s.Antilink && containsLink(getText(v.Message)) {
 		// نوٹ: takeSecurityAction کو بھی botID پاس کیا ہے تاکہ وہ Save کر سکے
@@ -148,19 +131,30 @@ func checkSecurity(client *whatsmeow.Client, v *events.Message) {
 
 	// Anti-picture check
 	if s.AntiPic && v.Message.ImageMessage != nil {
-		takeSecurityAction(client, v, s, "delete", "Image not allowed", botID)
+		takeSecurityAction(client, v, s, toggleAction(s, "antipic"), "Image not allowed", botID)
 		return
 	}
 
 	// Anti-video check
 	if s.AntiVideo && v.Message.VideoMessage != nil {
-		takeSecurityAction(client, v, s, "delete", "Video not allowed", botID)
+		takeSecurityAction(client, v, s, toggleAction(s, "antivideo"), "Video not allowed", botID)
 		return
 	}
 
 	// Anti-sticker check
 	if s.AntiSticker && v.Message.StickerMessage != nil {
-		takeSecurityAction(client, v, s, "delete", "Sticker not allowed", botID)
+		takeSecurityAction(client, v, s, toggleAction(s, "antisticker"), "Sticker not allowed", botID)
+		return
+	}
+
+	// 🔞 NSFW تصاویر / اسٹیکرز (لوکل ماڈل)
+	if checkNSFW(client, v, s, botID) {
+		return
+	}
+
+	// 📎 باقی میڈیا ٹائپس (ڈاکیومنٹ، آڈیو، پول وغیرہ)
+	if mediaType, reason := matchMediaRule(v, s); mediaType != "" {
+		takeSecurityAction(client, v, s, s.MediaRules[mediaType], reason, botID)
 		return
 	}
 }
@@ -241,6 +235,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 			replyMessage(client, v, "⚠️ Failed to Delete (Give me Admin Rights)")
 			return
 		}
+		logModAction(client, v.Info.Chat, "delete", reason, types.EmptyJID, v.Info.Sender, getText(v.Message))
 
 		// نوٹیفکیشن بھیجیں
 		msg := fmt.Sprintf(`╔════════════════╗
@@ -274,6 +269,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 			replyMessage(client, v, "⚠️ Failed to Kick (Give me Admin Rights)")
 			return
 		}
+		logModAction(client, v.Info.Chat, "kick", reason, types.EmptyJID, v.Info.Sender, getText(v.Message))
 		
 		msg := fmt.Sprintf(`╔════════════════╗
 ║ 👢 KICKED
@@ -309,6 +305,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 				replyMessage(client, v, "⚠️ Failed to Kick (User has 3 warnings)")
 			} else {
 				delete(s.Warnings, senderKey)
+				logModAction(client, v.Info.Chat, "kick", reason+" (3/3 warnings)", types.EmptyJID, v.Info.Sender, getText(v.Message))
 				
 				msg := fmt.Sprintf(`╔════════════════╗
 ║ 🚫 KICKED
@@ -327,6 +324,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 				})
 			}
 		} else {
+			logModAction(client, v.Info.Chat, "warn", fmt.Sprintf("%s (%d/3)", reason, warnCount), types.EmptyJID, v.Info.Sender, getText(v.Message))
 			msg := fmt.Sprintf(`╔════════════════╗
 ║ ⚠️ WARNING
 ╠════════════════╣
@@ -352,305 +350,6 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 }
 
 
-// مثال کے طور پر
-func onResponse(client *whatsmeow.Client, v *events.Message, choice string) {
-	senderID := v.Info.Sender.String()
-	state, exists := setupMap[senderID]
-
-	// 1. کیا یہ بندہ سیٹ اپ موڈ میں ہے؟
-	if !exists { return }
-
-	// 2. کیا اس نے میسج کو ریپلائی (Quote) کیا ہے؟
-	if v.Message.GetExtendedTextMessage().GetContextInfo() == nil {
-		return // اگر ریپلائی نہیں ہے تو خاموش رہے
-	}
-
-	// 3. کیا ریپلائی اسی بوٹ کے میسج کو کیا گیا ہے؟
-	quotedID := v.Message.ExtendedTextMessage.ContextInfo.GetStanzaID() // ✅ Fixed: ID caps mein
-	if quotedID != state.BotMsgID {
-		return // اگر کسی اور کے میسج کو ریپلائی کیا تو اگنور کریں
-	}
-
-	// 4. اگر سب ٹھیک ہے تو ریڈیس میں سیو کریں
-	key := fmt.Sprintf("group:sec:%s:%s:%s", state.BotLID, state.GroupID, state.Type)
-	rdb.Set(context.Background(), key, choice, 0)
-
-	// اگلا مینیو دکھائیں یا ختم کریں
-	replyMessage(client, v, "✅ Setting Saved Successfully!")
-	delete(setupMap, senderID)
-}
-
-func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []string, secType string) {
-	// 1️⃣ گروپ چیک
-	if !v.Info.IsGroup {
-		replyMessage(client, v, "❌ This command is for Groups only.")
-		return
-	}
-
-	// 2️⃣ ایڈمن چیک (صرف ایڈمن، جیسا آپ نے کہا)
-	// ایرر فکس: isAdmin کو اب ہم IDs پاس کر رہے ہیں
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) {
-		replyMessage(client, v, "👮 Only Group Admins can use this command.")
-		return
-	}
-
-	// 🛠️ سیٹنگز لوڈ کریں
-	botID := getCleanID(client.Store.ID.User)
-	groupID := v.Info.Chat.String()
-	settings := getGroupSettings(botID, groupID)
-
-	// کمانڈ کا پہلا لفظ (on, off, یا خالی)
-	cmd := ""
-	if len(args) > 0 {
-		cmd = strings.ToLower(args[0])
-	}
-
-	// ===========================
-	// 🟢 CASE 1: STATUS (اگر کچھ نہ لکھا ہو)
-	// ===========================
-	if cmd == "" {
-		status := "🔴 DISABLED"
-		if settings.Antilink { // یہاں چیک کر لیں کہ variable کا نام Antilink ہے یا کچھ اور
-			status = "🟢 ENABLED"
-		}
-
-		bypass := "❌ NO"
-		if settings.AntilinkAdmin {
-			bypass = "✅ YES"
-		}
-
-		action := "Delete Only"
-		if settings.AntilinkAction == "deletekick" {
-			action = "Delete + Kick"
-		} else if settings.AntilinkAction == "deletewarn" {
-			action = "Delete + Warn"
-		}
-
-		msg := fmt.Sprintf(`╔════════════════╗
-║ 🛡️ %s STATUS
-╠════════════════╣
-║ Status: %s
-║ Admin Allow: %s
-║ Action: %s
-╠════════════════╣
-║ Use: .%s on/off
-╚════════════════╝`, strings.ToUpper(secType), status, bypass, action, secType)
-
-		replyMessage(client, v, msg)
-		return
-	}
-
-	// ===========================
-	// 🔴 CASE 2: OFF (بند کرنا)
-	// ===========================
-	if cmd == "off" {
-		// اگر آپ کے پاس ہر ٹائپ کے لیے الگ variable ہے تو یہاں switch لگا لیں
-		// فی الحال میں generic save کر رہا ہوں
-		if secType == "antilink" { settings.Antilink = false }
-		// باقی ٹائپس (antipic, antivideo) کے لیے یہاں کوڈ ایڈ کریں اگر وہ settings سٹرکچر میں ہیں
-		
-		saveGroupSettings(botID, settings)
-		replyMessage(client, v, fmt.Sprintf("✅ %s has been DISABLED.", secType))
-		return
-	}
-
-	// ===========================
-	// 🔵 CASE 3: ON (وزرڈ سٹارٹ کریں)
-	// ===========================
-	if cmd == "on" {
-		startWizard(client, v, secType, botID, groupID)
-		return
-	}
-	
-	replyMessage(client, v, "⚠️ Invalid Usage. Use: on, off or empty.")
-}
-
-
-// یہ وہ فنکشن ہے جو اصل سیٹ اپ شروع کرے گا (StartSecuritySetup کا نیا نام)
-func startWizard(client *whatsmeow.Client, v *events.Message, secType, botID, groupID string) {
-	msgText := fmt.Sprintf(`╔════════════════╗
-║ 🛡️ %s SETUP (1/2)
-╠════════════════╣
-║ Allow Admins to send links?
-║ 1️⃣ YES (Admins Safe)
-║ 2️⃣ NO (Check Admins too)
-╚════════════════╝`, strings.ToUpper(secType))
-
-	resp, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(msgText)},
-	})
-
-	if err != nil { return }
-
-	// سیشن محفوظ کریں
-	setupMap[resp.ID] = &SetupState{
-		Type:     secType,
-		Stage:    1,
-		GroupID:  groupID,
-		User:     v.Info.Sender.User,
-		BotLID:   botID,
-		BotMsgID: resp.ID,
-	}
-
-	// ٹائمر
-	go func() {
-		time.Sleep(2 * time.Minute)
-		delete(setupMap, resp.ID)
-	}()
-}
-
-
-func handleSetupResponse(client *whatsmeow.Client, v *events.Message) {
-	// 🛑 ریپلائی چیک
-	extMsg := v.Message.GetExtendedTextMessage()
-	if extMsg == nil || extMsg.ContextInfo == nil {
-		return
-	}
-
-	quotedID := extMsg.ContextInfo.GetStanzaID()
-	incomingLID := v.Info.Sender.User 
-
-	// ✅ FIX: Bot ID نکالیں
-	rawBotID := client.Store.ID.User
-	botID := getCleanID(rawBotID)
-
-	// 1. ڈیٹا تلاش کریں (جس میسج پر ریپلائی آیا ہے)
-	state, exists := setupMap[quotedID]
-	if !exists {
-		// اگر یہاں نہیں ملا، تو ہو سکتا ہے یہ کسی دوسرے بوٹ کا میسج ہو
-		return
-	}
-
-	// 2. بوٹ میچنگ
-	if state.BotLID != botID {
-		return // یہ سیشن اس بوٹ کا نہیں ہے
-	}
-
-	// 3. یوزر میچنگ
-	fmt.Printf("🔍 [SETUP MATCH] Stage: %d | User: %s vs %s\n", state.Stage, state.User, incomingLID)
-
-	if state.User != incomingLID {
-		fmt.Println("🚫 [REJECTED] User mismatch in setup.")
-		return 
-	}
-
-	txt := strings.TrimSpace(getText(v.Message))
-
-	// ✅ FIX: Settings منگواتے وقت botID پاس کریں
-	s := getGroupSettings(botID, state.GroupID)
-
-	// ===========================
-	// 🔄 STAGE 1 LOGIC
-	// ===========================
-	if state.Stage == 1 {
-		if txt == "1" {
-			s.AntilinkAdmin = true
-		} else if txt == "2" {
-			s.AntilinkAdmin = false
-		} else {
-			replyMessage(client, v, "⚠️ Please reply with 1 or 2")
-			return
-		}
-
-		// پرانا سیشن ڈیلیٹ کریں (کیونکہ اب ہم نیا میسج بھیج رہے ہیں)
-		delete(setupMap, quotedID)
-
-		// اگلا میسج بھیجیں
-		nextMsg := fmt.Sprintf(`╔════════════════╗
-║ ⚡ %s (2/2)
-╠════════════════╣
-║ 1️⃣ DELETE ONLY
-║ 2️⃣ DELETE + KICK
-║ 3️⃣ DELETE + WARN
-╚════════════════╝`, strings.ToUpper(state.Type))
-
-		resp, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-			ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(nextMsg)},
-		})
-
-		if err != nil {
-			fmt.Println("❌ Error sending Stage 2 msg:", err)
-			return
-		}
-
-		// ✅ نیا سیشن (Stage 2) سیو کریں
-		newKey := resp.ID
-		fmt.Printf("⏭️ [NEXT STAGE] Moving to Stage 2. New Key: %s\n", newKey)
-
-		setupMap[newKey] = &SetupState{
-			Type:     state.Type,
-			Stage:    2, // سٹیج اپڈیٹ
-			GroupID:  state.GroupID,
-			User:     state.User,
-			BotLID:   state.BotLID, // وہی Bot ID رکھیں
-			BotMsgID: resp.ID,
-		}
-		
-		// اس نئے سیشن کے لیے بھی ٹائمر لگا دیں
-		go func() {
-			time.Sleep(2 * time.Minute)
-			delete(setupMap, newKey)
-		}()
-		
-		return
-	}
-
-	// ===========================
-	// 🔄 STAGE 2 LOGIC
-	// ===========================
-	if state.Stage == 2 {
-		var actionText string
-		switch txt {
-		case "1":
-			s.AntilinkAction = "delete"
-			actionText = "Delete Only"
-		case "2":
-			s.AntilinkAction = "deletekick"
-			actionText = "Delete + Kick"
-		case "3":
-			s.AntilinkAction = "deletewarn"
-			actionText = "Delete + Warn"
-		default:
-			replyMessage(client, v, "⚠️ Please reply with 1, 2 or 3")
-			return
-		}
-
-		// فائنل سیٹنگز اپلائی کریں
-		applySecurityFinal(s, state.Type, true)
-
-		// ✅ FIX: Save کرتے وقت botID پاس کریں (تاکہ Redis میں صحیح سیو ہو)
-		saveGroupSettings(botID, s)
-		
-		// سیشن ختم
-		delete(setupMap, quotedID) 
-
-		adminBypass := "YES ✅"
-		if !s.AntilinkAdmin {
-			adminBypass = "NO ❌"
-		}
-		
-		finalMsg := fmt.Sprintf(`╔════════════════╗
-║ ✅ %s ENABLED
-╠════════════════╣
-║ Admin Bypass: %s
-║ Action: %s
-╚════════════════╝`, strings.ToUpper(state.Type), adminBypass, actionText)
-
-		replyMessage(client, v, finalMsg)
-		fmt.Printf("🏁 [COMPLETE] Setup Success for %s on Bot %s\n", state.Type, botID)
-	}
-}
-
-// ہیلپر
-func applySecurityFinal(s *GroupSettings, t string, val bool) {
-	switch t {
-	case "antilink": s.Antilink = val
-	case "antipic": s.AntiPic = val
-	case "antivideo": s.AntiVideo = val
-	case "antisticker": s.AntiSticker = val
-	}
-}
-
 // ہیلپر فنکشن ایڈمن چیک کے لیے
 func participantIsAdmin(p types.GroupParticipant) bool {
 	return p.IsAdmin || p.IsSuperAdmin
@@ -680,275 +379,49 @@ func handleGroupInfoChange(client *whatsmeow.Client, v *events.GroupInfo) {
 
 	// ✅ 2. اب botID پاس کریں
 	settings := getGroupSettings(botID, chatID)
-	
-	if !settings.Welcome { return }
-
-	// 🛡️ ANTI-SPAM FILTER
-	if RestrictedGroups[chatID] {
-		if !AuthorizedBots[botID] {
-			return 
-		}
-	}
-
-	// ... (باقی ویلکم لاجک) ...
-	// =========================================================
 
-    // ⚡ 4. Event Processing (Join, Leave, Promote, Demote)
-    
-	// ✅ کک یا لیو (Leave/Kick)
-	if v.Leave != nil && len(v.Leave) > 0 {
-		for _, left := range v.Leave {
-			sender := v.Sender 
-			leftStr := left.String()
-            // نام نکالنے کی کوشش (Optional)
-            userNum := strings.Split(left.User, "@")[0]
+	// 🔐 لاک شدہ نام/تفصیل کی غیر مجاز تبدیلی واپس
+	enforceMetaLocks(client, v)
 
-			if sender.User == left.User {
-                // خود لیفٹ ہوا
-				msg := fmt.Sprintf(`╔════════════════╗
-║ 👋 GOODBYE
-╠════════════════╣
-║ 👤 User: @%s
-║ 📉 Status: Left
-╚════════════════╝`, userNum)
-
-				client.SendMessage(context.Background(), v.JID, &waProto.Message{
-					ExtendedTextMessage: &waProto.ExtendedTextMessage{
-						Text: proto.String(msg),
-						ContextInfo: &waProto.ContextInfo{
-							MentionedJID: []string{leftStr},
-						},
-					},
-				})
-			} else {
-                // کک کیا گیا (By Admin)
-				msg := fmt.Sprintf(`╔════════════════╗
-║ 👢 KICKED
-╠════════════════╣
-║ 👤 User: @%s
-║ 👮 By: @%s
-╚════════════════╝`, userNum, sender.User)
+	// 🛡️ ایڈمن گارڈ: غیر مجاز ڈیموٹ/کک/پروموٹ الٹ دیں
+	guardAdminChanges(client, settings, v)
 
-				client.SendMessage(context.Background(), v.JID, &waProto.Message{
-					ExtendedTextMessage: &waProto.ExtendedTextMessage{
-						Text: proto.String(msg),
-						ContextInfo: &waProto.ContextInfo{
-							MentionedJID: []string{leftStr, sender.String()},
-						},
-					},
-				})
-			}
-            time.Sleep(500 * time.Millisecond) // چھوٹا سا وقفہ تاکہ واٹس ایپ بین نہ کرے
-		}
+	// 🚫 بین شدہ یوزرز کو نکالیں (ویلکم سے پہلے، ویلکم آف ہو تب بھی)
+	if len(v.Join) > 0 {
+		v.Join = enforceBans(client, v)
+		recordJoins(v.JID, v.Join)
 	}
 
-	// ✅ Promote event
-	if v.Promote != nil && len(v.Promote) > 0 {
-		for _, promoted := range v.Promote {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👑 PROMOTED
-╠════════════════╣
-║ 👤 User: @%s
-║ 🎉 New Admin!
-╚════════════════╝`, promoted.User)
-
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{promoted.String()},
-					},
-				},
-			})
+	// 🚨 ریڈ ڈیٹیکشن (برسٹ کک ہو چکا ہو تو آگے کچھ نہ کریں)
+	if settings.AntiRaid && len(v.Join) > 0 {
+		if trackJoinsForRaid(client, settings, v) && settings.RaidKick {
+			v.Join = nil
 		}
 	}
 
-	// ✅ Demote event
-	if v.Demote != nil && len(v.Demote) > 0 {
-		for _, demoted := range v.Demote {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👤 DEMOTED
-╠════════════════╣
-║ 👤 User: @%s
-║ 📉 Admin Removed
-╚════════════════╝`, demoted.User)
-
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{demoted.String()},
-					},
-				},
-			})
-		}
-	}
-
-	// ✅ Join event (Welcome)
-	if v.Join != nil && len(v.Join) > 0 {
+	// 🧩 نئے ممبرز کے لیے ویریفکیشن
+	if settings.Captcha {
 		for _, joined := range v.Join {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👋 WELCOME
-╠════════════════╣
-║ 👤 User: @%s
-║ 🎉 Enjoy here!
-╚════════════════╝`, joined.User)
-
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{joined.String()},
-					},
-				},
-			})
-            time.Sleep(500 * time.Millisecond)
+			startCaptcha(client, settings, v.JID, joined)
 		}
 	}
-}
-
-//bug 🪲 🐛 menu
-
-// خطرناک کیریکٹرز کی لسٹ
-
-
-// ---------------------------------------------------------
-// 1. COMMAND: .antibug (Toggle ON/OFF)
-// ---------------------------------------------------------
-// یہ فنکشن اب ایرر نہیں دے گا کیونکہ یہ client اور message قبول کر رہا ہے
-func handleAntiBug(client *whatsmeow.Client, v *events.Message) {
-	AntiBugEnabled = !AntiBugEnabled
-	
-	status := "OFF ❌"
-	if AntiBugEnabled {
-		status = "ON ✅"
-	}
-
-	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		Conversation: proto.String("🛡️ *Anti-Bug System*\nStatus: " + status),
-	})
-}
 
-// ---------------------------------------------------------
-// 2. HELPER: Text Scanner (Logic)
-// ---------------------------------------------------------
-func extractText(m *waProto.Message) string {
-	if m.GetConversation() != "" {
-		return m.GetConversation()
-	}
-	if m.GetExtendedTextMessage() != nil {
-		return m.GetExtendedTextMessage().GetText()
-	}
-	return ""
-}
-
-// ---------------------------------------------------------
-// UPDATED: Aggressive Virus Scanner
-// ---------------------------------------------------------
-// ---------------------------------------------------------
-// 1. ADVANCED VIRUS SCANNER
-// ---------------------------------------------------------
-// ---------------------------------------------------------
-// 1. ADVANCED VIRUS SCANNER (Logic Based)
-// ---------------------------------------------------------
-func scanForVirus(msg string) bool {
-	// A. لمبائی چیک (Length Check)
-	if len(msg) > 4000 {
-		fmt.Println("⚠️ Message too long (Possible Crash Payload)")
-		return true
-	}
-
-	// B. خطرناک کریکٹرز (Dangerous Unicode)
-	dangerous := []string{
-		"\u202e", // Right-to-Left Override (Crash King)
-		"\u202d", // Left-to-Right Override
-		"\u202a", // LRE
-		"\u202b", // RLE
-		"\u200f", // RTL Mark
-		"\u200e", // LTR Mark
-	}
-
-	foundCount := 0
-	for _, char := range dangerous {
-		if strings.Contains(msg, char) {
-			foundCount++
+	// 🛡️ ANTI-SPAM FILTER
+	if RestrictedGroups[chatID] {
+		if !AuthorizedBots[botID] {
+			return 
 		}
 	}
 
-	// اگر ایک ہی میسج میں 3 سے زیادہ بار یہ نشانیاں ملیں
-	if foundCount >= 3 {
-		return true
-	}
-
-	// C. Repeater Check (Junk Flood)
-	if strings.Count(msg, "\u200b") > 10 { 
-		return true
-	}
+	// 👋 ویلکم / گڈ بائے / پروموٹ / ڈیموٹ نوٹس (ہر ایک کا الگ ٹوگل)
+	sendGroupNotices(client, settings, v)
 
-	return false
-}
-
-// ---------------------------------------------------------
-// 2. AUTO PROTECT ACTION (Fixed Build Errors)
-// ---------------------------------------------------------
-func AutoProtect(client *whatsmeow.Client, v *events.Message) bool {
-	// گروپ کو اگنور کریں
-	if v.Info.IsGroup {
-		return false
-	}
-
-	// ٹیکسٹ نکالیں
-	text := ""
-	if v.Message.GetConversation() != "" {
-		text = v.Message.GetConversation()
-	} else if v.Message.GetExtendedTextMessage() != nil {
-		text = v.Message.GetExtendedTextMessage().GetText()
-	}
-
-	if text == "" {
-		return false
-	}
-
-	// چیک کریں
-	if scanForVirus(text) {
-		sender := v.Info.Sender
-
-		fmt.Printf("🚨 VIRUS DETECTED from %s | ACTION: BLOCKING USER\n", sender.User)
-
-		// ✅ FIX: Assignment Mismatch Error Solved
-		// یہاں ہم نے _, err لگایا ہے تاکہ پہلا ویلیو اگنور ہو جائے اور صرف ایرر ملے
-		_, err := client.UpdateBlocklist(context.Background(), sender, events.BlocklistChangeActionBlock)
-		
-		if err != nil {
-			fmt.Println("❌ Block Failed:", err)
-		} else {
-			fmt.Println("✅ User Successfully Blocked to prevent crash.")
-		}
-		
-		return true
-	}
-
-	return false
+	// 📜 نئے ممبرز کو رولز
+	sendRulesToNewcomers(client, settings, v.JID, v.Join)
 }
 
+//bug 🪲 🐛 menu
 
-var badChars = []string{
-	"\u200b", // Zero Width Space
-	"\u200c", // ZWNJ
-	"\u200d", // ZWJ
-	"\u202a", // LRE
-	"\u202b", // RLE
-	"\u202c", // PDF
-	"\u202d", // LRO
-	"\u202e", // RLO
-	"\u2060", // Word Joiner
-	"\u2066", // LRI
-	"\u2067", // RLI
-	"\u2068", // FSI
-	"\u2069", // PDI
-	"\ufeff", // BOM
-	"\u200f", // RTL Mark
-}
 // ---------------------------------------------------------
 // 4. COMMAND: .send (Testing Tool)
 // ---------------------------------------------------------
@@ -16,16 +16,6 @@ import (
 
 // ==================== سیٹنگز سسٹم ====================
 func toggleAlwaysOnline(client *whatsmeow.Client, v *events.Message) {
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	status := "OFF 🔴"
 	statusText := "Disabled"
 	dataMutex.Lock()
@@ -51,16 +41,6 @@ func toggleAlwaysOnline(client *whatsmeow.Client, v *events.Message) {
 }
 
 func toggleAutoRead(client *whatsmeow.Client, v *events.Message) {
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	status := "OFF 🔴"
 	statusText := "Disabled"
 	dataMutex.Lock()
@@ -84,16 +64,6 @@ func toggleAutoRead(client *whatsmeow.Client, v *events.Message) {
 
 func toggleAutoReact(client *whatsmeow.Client, v *events.Message) {
 	// 1. Permission Check
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	// 2. Parse Arguments
 	// میسج سے ٹیکسٹ نکال کر چیک کریں کہ آگے "on" لکھا ہے یا "off"
 	body := strings.TrimSpace(getText(v.Message))
@@ -181,11 +151,6 @@ func saveGlobalSettings() {
 }
 
 func toggleAutoStatus(client *whatsmeow.Client, v *events.Message) {
-	if !isOwner(client, v.Info.Sender) {
-		replyMessage(client, v, "❌ Owner Only!")
-		return
-	}
-
 	// 1. آرگیومنٹس پارس کریں
 	body := strings.TrimSpace(getText(v.Message))
 	parts := strings.Fields(body)
@@ -233,11 +198,6 @@ func toggleAutoStatus(client *whatsmeow.Client, v *events.Message) {
 }
 
 func toggleStatusReact(client *whatsmeow.Client, v *events.Message) {
-	if !isOwner(client, v.Info.Sender) {
-		replyMessage(client, v, "❌ Owner Only!")
-		return
-	}
-
 	body := strings.TrimSpace(getText(v.Message))
 	parts := strings.Fields(body)
 
@@ -282,16 +242,6 @@ func toggleStatusReact(client *whatsmeow.Client, v *events.Message) {
 }
 
 func handleAddStatus(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	if len(args) < 1 {
 		msg := `╔════════════════╗
 ║ ⚠️ INVALID FORMAT
@@ -319,16 +269,6 @@ func handleAddStatus(client *whatsmeow.Client, v *events.Message, args []string)
 }
 
 func handleDelStatus(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	if len(args) < 1 {
 		msg := `╔════════════════╗
 ║ ⚠️ INVALID FORMAT
@@ -373,10 +313,6 @@ func handleDelStatus(client *whatsmeow.Client, v *events.Message, args []string)
 }
 
 func handleListStatus(client *whatsmeow.Client, v *events.Message) {
-	if !isOwner(client, v.Info.Sender) {
-		return
-	}
-
 	dataMutex.RLock()
 	targets := data.StatusTargets
 	dataMutex.RUnlock()
@@ -404,16 +340,6 @@ func handleListStatus(client *whatsmeow.Client, v *events.Message) {
 }
 
 func handleSetPrefix(client *whatsmeow.Client, v *events.Message, args []string) {
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	if len(args) < 1 {
 		msg := `╔════════════════╗
 ║ ⚠️ INVALID FORMAT
@@ -443,16 +369,6 @@ func handleSetPrefix(client *whatsmeow.Client, v *events.Message, args []string)
 
 func handleMode(client *whatsmeow.Client, v *events.Message, args []string) {
 	// Owner check
-	if !isOwner(client, v.Info.Sender) {
-		msg := `╔════════════════╗
-║ ❌ ACCESS DENIED
-╠════════════════╣
-║ 🔒 Owner Only
-╚════════════════╝`
-		replyMessage(client, v, msg)
-		return
-	}
-
 	// Private chat - Show Help
 	if !v.Info.IsGroup {
 		if len(args) < 1 {
@@ -529,10 +445,6 @@ func handleMode(client *whatsmeow.Client, v *events.Message, args []string) {
 }
 
 func handleReadAllStatus(client *whatsmeow.Client, v *events.Message) {
-	if !isOwner(client, v.Info.Sender) {
-		return
-	}
-
 	client.MarkRead(context.Background(), []types.MessageID{v.Info.ID}, time.Now(), types.NewJID("status@broadcast", types.DefaultUserServer), v.Info.Sender, types.ReceiptTypeRead)
 
 	msg := `╔════════════════╗
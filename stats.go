@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📊 MEMBER ACTIVITY STATS & LEADERBOARDS
+// ════════════════════════════════════════════════════════════════
+// Daily:  Redis Hash "stats:<botID>:<chatID>:<YYYYMMDD>" (field "<jid>|t/m/s" -> count، 35 دن TTL)
+//         jid = canonicalUserJID (LID میپ ہو تو فون نمبر والی)
+// Total:  Redis Hash "stats_total:<botID>:<chatID>" (اسی فارمیٹ میں آل ٹائم رول اپ)
+// کیز فی بوٹ ہیں تاکہ ایک گروپ میں کئی بوٹس ہوں تو گنتی دگنی نہ ہو۔
+// Weekly: Redis Set "stats_weekly" (botID|chatID)، Hash "stats_weekly_last" (-> آخری ISO ہفتہ)
+// دن گروپ کے ٹائم زون (scheduleLocation) میں گنے جاتے ہیں۔
+// GroupSettings.StatsOff والے گروپس میں کچھ بھی ریکارڈ نہیں ہوتا۔
+
+type memberStats struct {
+	JID     types.JID
+	Text    int
+	Media   int
+	Sticker int
+}
+
+func (m *memberStats) Total() int {
+	return m.Text + m.Media + m.Sticker
+}
+
+const (
+	statsDayTTL      = 35 * 24 * time.Hour
+	statsTopMax      = 10
+	statsWeeklyHour  = 9 // پیر صبح 9 بجے کے بعد
+	statsWatchPeriod = 10 * time.Minute
+)
+
+func statsDayKey(botID, chatID string, day time.Time) string {
+	return "stats:" + botID + ":" + chatID + ":" + day.Format("20060102")
+}
+
+func statsTotalKey(botID, chatID string) string {
+	return "stats_total:" + botID + ":" + chatID
+}
+
+func isoWeekLabel(t time.Time) string {
+	y, w := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", y, w)
+}
+
+// t = ٹیکسٹ، m = میڈیا، s = اسٹیکر، "" = شمار نہیں (ری ایکشن وغیرہ)
+func messageStatsKind(m *waProto.Message) string {
+	switch {
+	case m == nil:
+		return ""
+	case m.StickerMessage != nil:
+		return "s"
+	case m.ImageMessage != nil, m.VideoMessage != nil, m.AudioMessage != nil,
+		m.DocumentMessage != nil, m.PtvMessage != nil:
+		return "m"
+	case m.Conversation != nil, m.ExtendedTextMessage != nil:
+		return "t"
+	}
+	return ""
+}
+
+// processMessage سے ہر گروپ میسج پر
+func recordMessageStats(client *whatsmeow.Client, v *events.Message) {
+	if rdb == nil {
+		return
+	}
+	kind := messageStatsKind(v.Message)
+	if kind == "" {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	if s.StatsOff {
+		return
+	}
+
+	chatID := v.Info.Chat.String()
+	dayKey := statsDayKey(botID, chatID, time.Now().In(scheduleLocation(s)))
+	field := canonicalUserJID(client, v.Info.Sender).String() + "|" + kind // PN اور LID ایک ہی قطار
+
+	pipe := rdb.Pipeline()
+	pipe.HIncrBy(ctx, dayKey, field, 1)
+	pipe.Expire(ctx, dayKey, statsDayTTL)
+	pipe.HIncrBy(ctx, statsTotalKey(botID, chatID), field, 1)
+	pipe.Exec(ctx)
+}
+
+func mergeStats(into map[string]*memberStats, raw map[string]string) {
+	for field, val := range raw {
+		parts := strings.SplitN(field, "|", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		n, _ := strconv.Atoi(val)
+		m := into[parts[0]]
+		if m == nil {
+			jid, err := types.ParseJID(parts[0])
+			if err != nil {
+				continue
+			}
+			m = &memberStats{JID: jid}
+			into[parts[0]] = m
+		}
+		switch parts[1] {
+		case "t":
+			m.Text += n
+		case "m":
+			m.Media += n
+		case "s":
+			m.Sticker += n
+		}
+	}
+}
+
+// end سے پیچھے days دن جمع کریں؛ days = 0 → آل ٹائم
+func loadStats(client *whatsmeow.Client, chatID string, end time.Time, days int) map[string]*memberStats {
+	out := make(map[string]*memberStats)
+	if rdb == nil {
+		return out
+	}
+	botID := getCleanID(client.Store.ID.User)
+	if days == 0 {
+		raw, _ := rdb.HGetAll(ctx, statsTotalKey(botID, chatID)).Result()
+		mergeStats(out, raw)
+		return mergeAliasRows(client, out)
+	}
+	pipe := rdb.Pipeline()
+	var cmds []*redis.MapStringStringCmd
+	for i := 0; i < days; i++ {
+		cmds = append(cmds, pipe.HGetAll(ctx, statsDayKey(botID, chatID, end.AddDate(0, 0, -i))))
+	}
+	pipe.Exec(ctx)
+	for _, c := range cmds {
+		mergeStats(out, c.Val())
+	}
+	return mergeAliasRows(client, out)
+}
+
+// LID میپنگ بعد میں ملی ہو تو پرانی LID قطار فون نمبر والی میں شامل کریں
+func mergeAliasRows(client *whatsmeow.Client, stats map[string]*memberStats) map[string]*memberStats {
+	for key, m := range stats {
+		if m.JID.Server != types.HiddenUserServer {
+			continue
+		}
+		canon := canonicalUserJID(client, m.JID)
+		if canon == m.JID {
+			continue
+		}
+		into := stats[canon.String()]
+		if into == nil {
+			into = &memberStats{JID: canon}
+			stats[canon.String()] = into
+		}
+		into.Text += m.Text
+		into.Media += m.Media
+		into.Sticker += m.Sticker
+		delete(stats, key)
+	}
+	return stats
+}
+
+func rankStats(stats map[string]*memberStats) []*memberStats {
+	list := make([]*memberStats, 0, len(stats))
+	for _, m := range stats {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Total() != list[j].Total() {
+			return list[i].Total() > list[j].Total()
+		}
+		return list[i].JID.User < list[j].JID.User
+	})
+	return list
+}
+
+func findMemberStats(client *whatsmeow.Client, stats map[string]*memberStats, user types.JID) (*memberStats, int) {
+	aliases := make(map[string]bool)
+	for _, a := range getUserAliases(client, user) {
+		aliases[a] = true
+	}
+	for i, m := range rankStats(stats) {
+		if aliases[getCleanID(m.JID.User)] {
+			return m, i + 1
+		}
+	}
+	return &memberStats{JID: user}, 0
+}
+
+func formatLeaderboard(title, period string, list []*memberStats) (string, []string) {
+	medals := []string{"🥇", "🥈", "🥉"}
+	total := 0
+	for _, m := range list {
+		total += m.Total()
+	}
+
+	out := "╔════════════════╗\n"
+	out += "║ " + title + "\n"
+	out += "║ 📅 " + period + "\n"
+	out += "╠════════════════╣\n"
+	if len(list) == 0 {
+		out += "║ 📭 No messages yet\n"
+	}
+	var mentions []string
+	for i, m := range list {
+		if i >= statsTopMax {
+			break
+		}
+		rank := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			rank = medals[i]
+		}
+		out += fmt.Sprintf("║ %s @%s — %d\n", rank, m.JID.User, m.Total())
+		out += fmt.Sprintf("║    💬 %d  🖼️ %d  🎭 %d\n", m.Text, m.Media, m.Sticker)
+		mentions = append(mentions, m.JID.String())
+	}
+	out += "╠════════════════╣\n"
+	out += fmt.Sprintf("║ 📨 Messages: %d\n", total)
+	out += fmt.Sprintf("║ 👥 Active: %d\n", len(list))
+	out += "╚════════════════╝"
+	return out, mentions
+}
+
+// ==================== ہفتہ وار خلاصہ ====================
+
+func startStatsWatcher() {
+	ticker := time.NewTicker(statsWatchPeriod)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			fields, err := rdb.SMembers(ctx, "stats_weekly").Result()
+			if err != nil {
+				continue
+			}
+			for _, field := range fields {
+				parts := strings.SplitN(field, "|", 2)
+				if len(parts) != 2 {
+					rdb.SRem(ctx, "stats_weekly", field)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[parts[0]]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue
+				}
+
+				chat, ok := parseJID(parts[1])
+				if !ok {
+					rdb.SRem(ctx, "stats_weekly", field)
+					continue
+				}
+				s := getGroupSettings(parts[0], chat.String())
+				if s.StatsOff {
+					continue
+				}
+				now := time.Now().In(scheduleLocation(s))
+				week := isoWeekLabel(now)
+				if now.Weekday() == time.Monday && now.Hour() < statsWeeklyHour {
+					continue
+				}
+				if last, _ := rdb.HGet(ctx, "stats_weekly_last", field).Result(); last == week {
+					continue
+				}
+				rdb.HSet(ctx, "stats_weekly_last", field, week)
+				sendWeeklySummary(botClient, chat, now)
+			}
+		}
+	}()
+}
+
+// پچھلے 7 مکمل دن (کل تک)
+func sendWeeklySummary(client *whatsmeow.Client, chat types.JID, now time.Time) {
+	end := now.AddDate(0, 0, -1)
+	start := now.AddDate(0, 0, -7)
+	list := rankStats(loadStats(client, chat.String(), end, 7))
+	period := start.Format("02 Jan") + " - " + end.Format("02 Jan")
+	out, mentions := formatLeaderboard("📊 WEEKLY SUMMARY", period, list)
+	sendMentionText(client, chat, out, mentions)
+	fmt.Printf("📊 [STATS] Weekly summary sent to %s\n", chat.User)
+}
+
+// ==================== کمانڈز ====================
+
+// .top [day|week|month|all]
+func handleTop(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	s := getGroupSettings(getCleanID(client.Store.ID.User), v.Info.Chat.String())
+	if s.StatsOff {
+		replyMessage(client, v, "📴 Activity stats are disabled in this group.")
+		return
+	}
+
+	period := "day"
+	if len(args) > 0 {
+		period = strings.ToLower(args[0])
+	}
+	periods := map[string]int{"day": 1, "today": 1, "week": 7, "month": 30, "all": 0}
+	days, ok := periods[period]
+	if !ok {
+		replyMessage(client, v, "⚠️ Usage: .top [day|week|month|all]")
+		return
+	}
+
+	now := time.Now().In(scheduleLocation(s))
+	label := "All time"
+	switch days {
+	case 1:
+		label = "Today, " + now.Format("02 Jan")
+	case 7, 30:
+		label = fmt.Sprintf("Last %d days", days)
+	}
+	out, mentions := formatLeaderboard("🏆 TOP MEMBERS", label, rankStats(loadStats(client, v.Info.Chat.String(), now, days)))
+	sendMentionText(client, v.Info.Chat, out, mentions)
+}
+
+// .stats @user | .stats me | .stats on/off | .stats weekly on/off | .stats reset
+func handleMemberStats(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	s := getGroupSettings(botID, chat.String())
+	field := botID + "|" + chat.String()
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "on", "off":
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		s.StatsOff = sub == "off"
+		saveGroupSettings(botID, s)
+		if s.StatsOff {
+			replyMessage(client, v, "❌ *Activity Stats:* OFF\nNo messages will be counted in this group.")
+		} else {
+			replyMessage(client, v, "✅ *Activity Stats:* ON")
+		}
+		return
+
+	case "weekly":
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		mode := ""
+		if len(args) > 1 {
+			mode = strings.ToLower(args[1])
+		}
+		switch mode {
+		case "on":
+			rdb.SAdd(ctx, "stats_weekly", field)
+			// اسی ہفتے فوراً پوسٹ نہ ہو
+			rdb.HSet(ctx, "stats_weekly_last", field, isoWeekLabel(time.Now().In(scheduleLocation(s))))
+			replyMessage(client, v, "✅ *Weekly Summary:* ON\n📅 Posted every Monday after 09:00")
+		case "off":
+			rdb.SRem(ctx, "stats_weekly", field)
+			rdb.HDel(ctx, "stats_weekly_last", field)
+			replyMessage(client, v, "❌ *Weekly Summary:* OFF")
+		default:
+			replyMessage(client, v, "⚠️ Usage: .stats weekly on|off")
+		}
+		return
+
+	case "reset":
+		if !requireRole(client, v, RoleAdmin) {
+			return
+		}
+		// دن کی کیز statsDayTTL کے بعد خود ختم ہوتی ہیں، اس لیے صرف وہی دن (+ٹائم زون کا ایک دن)
+		now := time.Now().In(scheduleLocation(s))
+		keys := []string{statsTotalKey(botID, chat.String())}
+		for i := -1; i <= int(statsDayTTL/(24*time.Hour)); i++ {
+			keys = append(keys, statsDayKey(botID, chat.String(), now.AddDate(0, 0, -i)))
+		}
+		rdb.Del(ctx, keys...)
+		replyMessage(client, v, "🗑️ Activity stats cleared for this group.")
+		return
+	}
+
+	target := v.Info.Sender
+	if sub != "me" {
+		if jid, _ := resolveTarget(v, args); !jid.IsEmpty() {
+			target = jid
+		}
+	}
+	if s.StatsOff {
+		replyMessage(client, v, "📴 Activity stats are disabled in this group.")
+		return
+	}
+
+	now := time.Now().In(scheduleLocation(s))
+	today, _ := findMemberStats(client, loadStats(client, chat.String(), now, 1), target)
+	week, weekRank := findMemberStats(client, loadStats(client, chat.String(), now, 7), target)
+	month, _ := findMemberStats(client, loadStats(client, chat.String(), now, 30), target)
+	all, allRank := findMemberStats(client, loadStats(client, chat.String(), now, 0), target)
+
+	rankText := func(r int) string {
+		if r == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("#%d", r)
+	}
+	lastSeen := "Unknown"
+	if ts, err := rdb.HGet(ctx, activityKey(chat.String()), getCleanID(target.User)).Int64(); err == nil {
+		lastSeen = formatDuration(time.Since(time.Unix(ts, 0))) + " ago"
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 📊 MEMBER STATS
+╠════════════════╣
+║ 👤 @%s
+║ 🕒 Last active: %s
+╠════════════════╣
+║ 📅 Today: %d
+║ 📆 7 days: %d (rank %s)
+║ 🗓️ 30 days: %d
+║ ♾️ All time: %d (rank %s)
+╠════════════════╣
+║ 💬 Text: %d
+║ 🖼️ Media: %d
+║ 🎭 Stickers: %d
+╚════════════════╝`, target.User, lastSeen,
+		today.Total(), week.Total(), rankText(weekRank), month.Total(),
+		all.Total(), rankText(allRank), all.Text, all.Media, all.Sticker)
+	sendMentionText(client, chat, msg, []string{target.String()})
+}
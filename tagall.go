@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 📣 TAGALL / HIDETAG / ANNOUNCE
+// ════════════════════════════════════════════════════════════════
+// فلٹرز (میسج سے پہلے): admins | members | +92 (ملک کوڈ)
+// بڑے گروپس میں tagall کی لسٹ کئی میسجز میں بٹ جاتی ہے۔
+// کسی میسج کو ریپلائی کر کے tagall/hidetag → وہی میسج (میڈیا سمیت) مینشن کے ساتھ دوبارہ۔
+// Announcements: Redis Sorted Set "announcements" (score = unix، member = JSON)
+
+type TagFilter struct {
+	Role   string `json:"role"`   // "" / admins / members
+	Prefix string `json:"prefix"` // ملک کوڈ
+}
+
+type Announcement struct {
+	ID     string    `json:"id"`
+	BotID  string    `json:"bot_id"`
+	ChatID string    `json:"chat_id"`
+	Text   string    `json:"text"`
+	Filter TagFilter `json:"filter"`
+	By     string    `json:"by"`
+	At     time.Time `json:"at"`
+}
+
+const (
+	tagBatchSize  = 100
+	tagBatchDelay = 2 * time.Second
+)
+
+// ٹیکسٹ کے شروع سے فلٹر الفاظ نکالیں، باقی میسج واپس
+func parseTagFilter(text string) (TagFilter, string) {
+	var f TagFilter
+	for {
+		text = strings.TrimSpace(text)
+		word := text
+		if idx := strings.IndexAny(text, " \n"); idx >= 0 {
+			word = text[:idx]
+		}
+		lw := strings.ToLower(word)
+		switch {
+		case lw == "admins" || lw == "admin":
+			f.Role = "admins"
+		case lw == "members" || lw == "nonadmins":
+			f.Role = "members"
+		case len(lw) > 1 && lw[0] == '+' && strings.Trim(lw[1:], "0123456789") == "":
+			f.Prefix = lw[1:]
+		default:
+			return f, text
+		}
+		text = text[len(word):]
+	}
+}
+
+func (f TagFilter) String() string {
+	var parts []string
+	switch f.Role {
+	case "admins":
+		parts = append(parts, "Admins")
+	case "members":
+		parts = append(parts, "Members")
+	}
+	if f.Prefix != "" {
+		parts = append(parts, "+"+f.Prefix)
+	}
+	if len(parts) == 0 {
+		return "Everyone"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func filterParticipants(client *whatsmeow.Client, info *types.GroupInfo, f TagFilter) []types.JID {
+	var list []types.JID
+	for _, p := range info.Participants {
+		isAdm := participantIsAdmin(p)
+		if (f.Role == "admins" && !isAdm) || (f.Role == "members" && isAdm) {
+			continue
+		}
+		if f.Prefix != "" {
+			num := ""
+			if !p.PhoneNumber.IsEmpty() {
+				num = p.PhoneNumber.User
+			} else {
+				num = phoneNumberOf(client, p.JID)
+			}
+			if !strings.HasPrefix(num, f.Prefix) {
+				continue
+			}
+		}
+		list = append(list, p.JID)
+	}
+	return list
+}
+
+// کاپی شدہ میسج پر مینشنز لگائیں؛ غیر معاون ٹائپ پر nil
+func withMentions(msg *waProto.Message, mentions []string) *waProto.Message {
+	if msg == nil {
+		return nil
+	}
+	m := proto.Clone(msg).(*waProto.Message)
+	ci := &waProto.ContextInfo{MentionedJID: mentions}
+	switch {
+	case m.Conversation != nil:
+		return buildMentionText(m.GetConversation(), mentions)
+	case m.ExtendedTextMessage != nil:
+		m.ExtendedTextMessage.ContextInfo = ci
+	case m.ImageMessage != nil:
+		m.ImageMessage.ContextInfo = ci
+	case m.VideoMessage != nil:
+		m.VideoMessage.ContextInfo = ci
+	case m.DocumentMessage != nil:
+		m.DocumentMessage.ContextInfo = ci
+	case m.AudioMessage != nil:
+		m.AudioMessage.ContextInfo = ci
+	case m.StickerMessage != nil:
+		m.StickerMessage.ContextInfo = ci
+	default:
+		return nil
+	}
+	return m
+}
+
+func jidStrings(jids []types.JID) []string {
+	out := make([]string, len(jids))
+	for i, j := range jids {
+		out[i] = j.String()
+	}
+	return out
+}
+
+// نظر آنے والی لسٹ، بیچز میں
+func sendTagList(client *whatsmeow.Client, chat types.JID, targets []types.JID, header string, f TagFilter) {
+	for i := 0; i < len(targets); i += tagBatchSize {
+		end := i + tagBatchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batch := targets[i:end]
+
+		out := "╔════════════════╗\n"
+		out += "║ 📣 TAG ALL"
+		if len(targets) > tagBatchSize {
+			out += fmt.Sprintf(" (%d/%d)", i/tagBatchSize+1, (len(targets)+tagBatchSize-1)/tagBatchSize)
+		}
+		out += "\n╠════════════════\n"
+		if i == 0 && header != "" {
+			out += "║ 💬 " + header + "\n"
+		}
+		for _, t := range batch {
+			out += "║ @" + t.User + "\n"
+		}
+		if end == len(targets) {
+			out += fmt.Sprintf("║ 👥 Total: %d (%s)\n", len(targets), f.String())
+		}
+		out += "╚════════════════"
+
+		client.SendMessage(context.Background(), chat, buildMentionText(out, jidStrings(batch)))
+		if end < len(targets) {
+			time.Sleep(tagBatchDelay)
+		}
+	}
+}
+
+func sendHiddenTag(client *whatsmeow.Client, chat types.JID, text string, targets []types.JID) {
+	if text == "" {
+		text = "🔔 Hidden Tag"
+	}
+	client.SendMessage(context.Background(), chat, buildMentionText(text, jidStrings(targets)))
+}
+
+// ==================== کمانڈز ====================
+
+// .tagall [admins|members|+92] [message]  (یا کسی میسج کو ریپلائی)
+func handleTagAll(client *whatsmeow.Client, v *events.Message, args []string) {
+	handleTagCommand(client, v, false)
+}
+
+// .hidetag [admins|members|+92] [message]
+func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
+	handleTagCommand(client, v, true)
+}
+
+func handleTagCommand(client *whatsmeow.Client, v *events.Message, hidden bool) {
+	if !v.Info.IsGroup {
+		msg := `╔════════════════╗
+║ ❌ GROUP ONLY
+╠════════════════
+║ This command
+║ works only in
+║ group chats
+╚════════════════`
+		replyMessage(client, v, msg)
+		return
+	}
+
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Could not fetch group info.")
+		return
+	}
+	filter, text := parseTagFilter(commandText(v))
+	targets := filterParticipants(client, info, filter)
+	if len(targets) == 0 {
+		replyMessage(client, v, "📭 No members match "+filter.String())
+		return
+	}
+
+	// ↩️ ریپلائی موڈ: اصل میسج مینشنز کے ساتھ دوبارہ
+	if quoted := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage(); quoted != nil {
+		if msg := withMentions(quoted, jidStrings(targets)); msg != nil {
+			client.SendMessage(context.Background(), v.Info.Chat, msg)
+			if text != "" {
+				sendHiddenTag(client, v.Info.Chat, text, targets)
+			}
+			return
+		}
+	}
+
+	if hidden {
+		sendHiddenTag(client, v.Info.Chat, text, targets)
+		return
+	}
+	sendTagList(client, v.Info.Chat, targets, text, filter)
+}
+
+// ==================== اعلانات ====================
+
+// "23:00" (آج/کل، گروپ ٹائم زون) یا "30m" / "2h"
+func parseAnnounceTime(arg string, loc *time.Location) (time.Time, bool) {
+	if d, ok := parseDurationArg(arg); ok {
+		return time.Now().Add(d), true
+	}
+	h, m, ok := parseClock(arg)
+	if !ok {
+		return time.Time{}, false
+	}
+	now := time.Now().In(loc)
+	at := time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, loc)
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, true
+}
+
+func getAnnouncements(chatID string) []Announcement {
+	var list []Announcement
+	if rdb == nil {
+		return list
+	}
+	vals, _ := rdb.ZRange(ctx, "announcements", 0, -1).Result()
+	for _, val := range vals {
+		var a Announcement
+		if json.Unmarshal([]byte(val), &a) == nil && a.ChatID == chatID {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+func removeAnnouncement(id string) bool {
+	vals, _ := rdb.ZRange(ctx, "announcements", 0, -1).Result()
+	for _, val := range vals {
+		var a Announcement
+		if json.Unmarshal([]byte(val), &a) == nil && a.ID == id {
+			rdb.ZRem(ctx, "announcements", val)
+			return true
+		}
+	}
+	return false
+}
+
+func startAnnouncementWatcher() {
+	ticker := time.NewTicker(30 * time.Second)
+	go func() {
+		for range ticker.C {
+			if rdb == nil {
+				continue
+			}
+			now := strconv.FormatInt(time.Now().Unix(), 10)
+			vals, err := rdb.ZRangeByScore(ctx, "announcements", &redis.ZRangeBy{Min: "-inf", Max: now}).Result()
+			if err != nil {
+				continue
+			}
+			for _, val := range vals {
+				var a Announcement
+				if json.Unmarshal([]byte(val), &a) != nil {
+					rdb.ZRem(ctx, "announcements", val)
+					continue
+				}
+
+				clientsMutex.RLock()
+				botClient := activeClients[a.BotID]
+				clientsMutex.RUnlock()
+				if botClient == nil {
+					continue // بوٹ آن لائن آنے پر بھیج دیں گے
+				}
+				rdb.ZRem(ctx, "announcements", val)
+
+				chat, ok := parseJID(a.ChatID)
+				if !ok {
+					continue
+				}
+				info, err := botClient.GetGroupInfo(context.Background(), chat)
+				if err != nil {
+					fmt.Printf("⚠️ [ANNOUNCE] %s: %v\n", chat.User, err)
+					continue
+				}
+				sendHiddenTag(botClient, chat, a.Text, filterParticipants(botClient, info, a.Filter))
+				fmt.Printf("📣 [ANNOUNCE] Sent %s to %s\n", a.ID, chat.User)
+			}
+		}
+	}()
+}
+
+// .announce <23:00|30m> [admins|members|+92] <text> | list | del <n>
+func handleAnnounce(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+	loc := scheduleLocation(s)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "", "list":
+		list := getAnnouncements(chatID)
+		out := "╔════════════════╗\n"
+		out += "║ 📣 ANNOUNCEMENTS\n"
+		out += "╠════════════════╣\n"
+		if len(list) == 0 {
+			out += "║ 📭 None scheduled\n"
+		}
+		for i, a := range list {
+			out += fmt.Sprintf("║ %d. ⏰ %s (%s)\n║    %s\n", i+1, a.At.In(loc).Format("02 Jan 15:04"), a.Filter.String(), truncateText(a.Text, 40))
+		}
+		out += "╠════════════════╣\n"
+		out += "║ .announce 23:00 <text>\n"
+		out += "║ .announce 2h members <text>\n"
+		out += "║ .announce del <n>\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+		return
+
+	case "del", "delete", "cancel":
+		list := getAnnouncements(chatID)
+		n := 0
+		if len(args) > 1 {
+			n, _ = strconv.Atoi(args[1])
+		}
+		if n < 1 || n > len(list) || !removeAnnouncement(list[n-1].ID) {
+			replyMessage(client, v, "⚠️ Usage: .announce del <number> (see .announce list)")
+			return
+		}
+		replyMessage(client, v, fmt.Sprintf("🗑️ Announcement #%d cancelled.", n))
+		return
+	}
+
+	at, ok := parseAnnounceTime(args[0], loc)
+	if !ok {
+		replyMessage(client, v, "⚠️ Usage: .announce <HH:MM | 30m | 2h> [admins|members|+92] <text>")
+		return
+	}
+
+	// ٹائم کے بعد کا اصل ٹیکسٹ
+	rest := commandText(v)
+	rest = strings.TrimSpace(rest[len(args[0]):])
+	filter, text := parseTagFilter(rest)
+	if text == "" {
+		replyMessage(client, v, "⚠️ Please add the announcement text.")
+		return
+	}
+
+	a := Announcement{
+		ID:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		BotID:  botID,
+		ChatID: chatID,
+		Text:   text,
+		Filter: filter,
+		By:     v.Info.Sender.User,
+		At:     at,
+	}
+	jsonData, _ := json.Marshal(a)
+	rdb.ZAdd(ctx, "announcements", redis.Z{Score: float64(at.Unix()), Member: string(jsonData)})
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 📣 ANNOUNCEMENT SET
+╠════════════════╣
+║ ⏰ %s
+║ 🌍 %s
+║ 👥 %s
+╚════════════════╝`, at.In(loc).Format("Mon 02 Jan 15:04"), loc.String(), filter.String())
+	replyMessage(client, v, msg)
+}
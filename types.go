@@ -23,6 +23,43 @@ type GroupSettings struct {
 	AntiSticker    bool           `bson:"antisticker" json:"antisticker"`
 	Warnings       map[string]int `bson:"warnings" json:"warnings"`
 	Welcome        bool   `json:"welcome"`
+	Captcha        bool   `json:"captcha"`
+	CaptchaType    string `json:"captcha_type"`
+	CaptchaTimeout int    `json:"captcha_timeout"`
+	AntiRaid       bool   `json:"antiraid"`
+	RaidThreshold  int    `json:"raid_threshold"`
+	RaidWindow     int    `json:"raid_window"`
+	RaidCooldown   int    `json:"raid_cooldown"`
+	RaidRevokeLink bool   `json:"raid_revoke_link"`
+	RaidKick       bool   `json:"raid_kick"`
+	ModLogGroup    string `json:"modlog_group"`
+	AntiDelete     bool   `json:"antidelete"`
+	AntiDeleteMode string `json:"antidelete_mode"`
+	MediaRules     map[string]string `json:"media_rules"` // media type -> delete / deletewarn / deletekick
+	BlockedExts    []string          `json:"blocked_exts"`
+	ForwardLimit   int               `json:"forward_limit"`
+	ToggleActions  map[string]string `json:"toggle_actions"` // antipic/antivideo/antisticker -> action
+	AntiBug        bool              `json:"antibug"`
+	AntiBugAction  string            `json:"antibug_action"`
+	NSFW           bool              `json:"nsfw"`
+	NSFWThreshold  int               `json:"nsfw_threshold"` // فیصد
+	NSFWAction     string            `json:"nsfw_action"`
+	AutoRequests   bool              `json:"auto_requests"`
+	JoinPrefixes   []string          `json:"join_prefixes"` // ملک کوڈ، مثلاً 92
+	RequestCaptcha bool              `json:"request_captcha"`
+	Schedules      []GroupSchedule   `json:"schedules"`
+	Timezone       string            `json:"timezone"`
+	OpenMsg        string            `json:"schedule_open_msg"` // "" = ڈیفالٹ، "off" = بند
+	CloseMsg       string            `json:"schedule_close_msg"`
+	Greetings      map[string]GreetConfig `json:"greetings"` // welcome/goodbye/promote/demote
+	Rules          string            `json:"rules"`
+	RulesOnJoin    string            `json:"rules_on_join"` // "" / group / dm
+	AdminGuard     bool              `json:"admin_guard"`
+	StatsOff       bool              `json:"stats_off"`
+	XPEnabled      bool              `json:"xp"`
+	XPQuiet        bool              `json:"xp_quiet"` // لیول اپ اعلان بند (انعامات پھر بھی)
+	XPLevels       []int             `json:"xp_levels"`
+	XPRewards      map[string]string `json:"xp_rewards"` // لیول -> "promote" یا ٹائٹل
 }
 // ✅ نام کو TikTokState سے بدل کر TTState کر دیا گیا ہے
 type TTState struct {
@@ -65,12 +102,14 @@ type BotData struct {
 
 // SetupState بوٹ کے سیکیورٹی سیٹ اپ کے سیشن کو سنبھالتا ہے
 type SetupState struct {
-	Type     string // اینٹی لنک، اینٹی پک، وغیرہ (Feature Name)
-	Stage    int    // پہلا اسٹیج ہے یا دوسرا (Current Step)
-	GroupID  string // کس گروپ میں سیٹ اپ ہو رہا ہے
-	User     string // کون سا ایڈمن سیٹ اپ کر رہا ہے
-	BotLID   string // کس بوٹ کے ذریعے سیٹ اپ ہو رہا ہے (Multi-Bot Fix)
-	BotMsgID string // بوٹ کے بھیجے گئے کارڈ کی یونیک آئی ڈی (Reply Check)
+	Type      string    `json:"type"`       // اینٹی لنک، اینٹی پک، وغیرہ (Feature Name)
+	Stage     int       `json:"stage"`      // موجودہ اسٹیپ (1 سے شروع)
+	GroupID   string    `json:"group_id"`   // کس گروپ میں سیٹ اپ ہو رہا ہے
+	User      string    `json:"user"`       // کون سا ایڈمن سیٹ اپ کر رہا ہے
+	BotLID    string    `json:"bot_lid"`    // کس بوٹ کے ذریعے سیٹ اپ ہو رہا ہے (Multi-Bot Fix)
+	BotMsgID  string    `json:"bot_msg_id"` // بوٹ کے بھیجے گئے کارڈ کی یونیک آئی ڈی (Reply Check)
+	Answers   []string  `json:"answers"`    // پچھلے اسٹیپس کے جوابات (آخر میں اپلائی ہوں گے)
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // --- 🌍 GLOBAL VARIABLES ---
@@ -79,4 +118,5 @@ var (
 	data       BotData
 	dataMutex  sync.RWMutex
 	setupMap   = make(map[string]*SetupState)
+	setupMutex sync.RWMutex
 )
\ No newline at end of file
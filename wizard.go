@@ -0,0 +1,642 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ════════════════════════════════════════════════════════════════
+// 🧙 SECURITY SETUP WIZARD
+// ════════════════════════════════════════════════════════════════
+// ہر فیچر اپنے اسٹیپس خود ڈیفائن کرتا ہے۔ ہر اسٹیپ کا جواب بوٹ کے کارڈ
+// پر ریپلائی ہوتا ہے؛ جوابات جمع ہو کر آخر میں ایک ساتھ اپلائی ہوتے ہیں،
+// اس لیے "cancel" پر سیٹنگز ویسی ہی رہتی ہیں۔
+// Session: Redis "wizard:<cardMsgID>" + "wizard_user:<bot>:<chat>:<user>" (TTL)
+
+type WizardOption struct {
+	Label string
+	Value string
+}
+
+type WizardStep struct {
+	Question string
+	Options  []WizardOption                     // نمبر والے آپشنز
+	Validate func(input string) (string, error) // آپشنز نہ ہوں تو فری ٹیکسٹ
+	MinRole  map[string]Role                    // کسی آپشن کے لیے بڑا رول درکار ہو
+}
+
+type WizardFeature struct {
+	Title   string
+	Steps   []WizardStep
+	IsOn    func(s *GroupSettings) bool
+	Apply   func(s *GroupSettings, answers []string)
+	Disable func(s *GroupSettings)
+	Summary func(s *GroupSettings) []string
+	// بوٹ لیول فیچرز (anticall، antibug DM) گروپ سیٹنگز کی بجائے بوٹ کنفیگ بدلتے ہیں؛ سمری لائنز واپس
+	ApplyBot func(botID string, answers []string) []string
+}
+
+const wizardTTL = 2 * time.Minute
+
+var wizardNumbers = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣"}
+
+var actionStep = WizardStep{
+	Question: "What should happen to violators?",
+	Options: []WizardOption{
+		{"DELETE ONLY", "delete"},
+		{"DELETE + KICK", "deletekick"},
+		{"DELETE + WARN", "deletewarn"},
+	},
+}
+
+var yesNoStep = []WizardOption{
+	{"YES", "yes"},
+	{"NO", "no"},
+}
+
+func numberStep(question string, min, max int) WizardStep {
+	return WizardStep{
+		Question: fmt.Sprintf("%s (%d-%d)", question, min, max),
+		Validate: func(in string) (string, error) {
+			n, err := strconv.Atoi(in)
+			if err != nil || n < min || n > max {
+				return "", fmt.Errorf("Please reply with a number from %d to %d", min, max)
+			}
+			return strconv.Itoa(n), nil
+		},
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES ✅"
+	}
+	return "NO ❌"
+}
+
+func actionLabel(action string) string {
+	switch action {
+	case "deletekick":
+		return "Delete + Kick"
+	case "deletewarn":
+		return "Delete + Warn"
+	}
+	return "Delete Only"
+}
+
+func toggleAction(s *GroupSettings, feature string) string {
+	if a := s.ToggleActions[feature]; a != "" {
+		return a
+	}
+	return "delete"
+}
+
+// antipic / antivideo / antisticker ایک ہی طرح کے ہیں
+func mediaToggleFeature(title string, field func(s *GroupSettings) *bool, key string) *WizardFeature {
+	return &WizardFeature{
+		Title: title,
+		Steps: []WizardStep{actionStep},
+		IsOn:  func(s *GroupSettings) bool { return *field(s) },
+		Apply: func(s *GroupSettings, a []string) {
+			*field(s) = true
+			if s.ToggleActions == nil {
+				s.ToggleActions = make(map[string]string)
+			}
+			s.ToggleActions[key] = a[0]
+		},
+		Disable: func(s *GroupSettings) { *field(s) = false },
+		Summary: func(s *GroupSettings) []string {
+			return []string{"Action: " + actionLabel(toggleAction(s, key))}
+		},
+	}
+}
+
+var wizardFeatures = map[string]*WizardFeature{
+	"antilink": {
+		Title: "ANTILINK",
+		Steps: []WizardStep{
+			{
+				Question: "Allow Admins to send links?",
+				Options: []WizardOption{
+					{"YES (Admins Safe)", "yes"},
+					{"NO (Check Admins too)", "no"},
+				},
+			},
+			actionStep,
+		},
+		IsOn: func(s *GroupSettings) bool { return s.Antilink },
+		Apply: func(s *GroupSettings, a []string) {
+			s.Antilink = true
+			s.AntilinkAdmin = a[0] == "yes"
+			s.AntilinkAction = a[1]
+		},
+		Disable: func(s *GroupSettings) { s.Antilink = false },
+		Summary: func(s *GroupSettings) []string {
+			bypass := "NO ❌"
+			if s.AntilinkAdmin {
+				bypass = "YES ✅"
+			}
+			return []string{"Admin Bypass: " + bypass, "Action: " + actionLabel(s.AntilinkAction)}
+		},
+	},
+	"antipic":     mediaToggleFeature("ANTIPIC", func(s *GroupSettings) *bool { return &s.AntiPic }, "antipic"),
+	"antivideo":   mediaToggleFeature("ANTIVIDEO", func(s *GroupSettings) *bool { return &s.AntiVideo }, "antivideo"),
+	"antisticker": mediaToggleFeature("ANTISTICKER", func(s *GroupSettings) *bool { return &s.AntiSticker }, "antisticker"),
+	"captcha": {
+		Title: "CAPTCHA",
+		Steps: []WizardStep{
+			{
+				Question: "Which challenge should newcomers solve?",
+				Options: []WizardOption{
+					{"MATH (3 + 4 = ?)", "math"},
+					{"EMOJI (repeat an emoji)", "emoji"},
+				},
+			},
+			numberStep("Minutes allowed before kick?", 1, 60),
+		},
+		IsOn: func(s *GroupSettings) bool { return s.Captcha },
+		Apply: func(s *GroupSettings, a []string) {
+			s.Captcha = true
+			s.CaptchaType = a[0]
+			s.CaptchaTimeout, _ = strconv.Atoi(a[1])
+		},
+		Disable: func(s *GroupSettings) { s.Captcha = false },
+		Summary: func(s *GroupSettings) []string {
+			return []string{"Type: " + strings.ToUpper(s.CaptchaType), fmt.Sprintf("Timeout: %d min", s.CaptchaTimeout)}
+		},
+	},
+	"antiraid": {
+		Title: "ANTI-RAID",
+		Steps: []WizardStep{
+			numberStep("How many joins count as a raid?", 3, 100),
+			numberStep("Within how many seconds?", 10, 3600),
+			numberStep("Lockdown length in minutes?", 1, 1440),
+			{Question: "Revoke the invite link during a raid?", Options: yesNoStep},
+			{Question: "Kick everyone who joined in the burst?", Options: yesNoStep},
+		},
+		IsOn: func(s *GroupSettings) bool { return s.AntiRaid },
+		Apply: func(s *GroupSettings, a []string) {
+			s.AntiRaid = true
+			s.RaidThreshold, _ = strconv.Atoi(a[0])
+			s.RaidWindow, _ = strconv.Atoi(a[1])
+			s.RaidCooldown, _ = strconv.Atoi(a[2])
+			s.RaidRevokeLink = a[3] == "yes"
+			s.RaidKick = a[4] == "yes"
+		},
+		Disable: func(s *GroupSettings) { s.AntiRaid = false },
+		Summary: func(s *GroupSettings) []string {
+			threshold, window, cooldown := raidConfig(s)
+			return []string{
+				fmt.Sprintf("Trigger: %d joins / %s", threshold, formatDuration(window)),
+				"Cooldown: " + formatDuration(cooldown),
+				"Revoke Link: " + yesNo(s.RaidRevokeLink),
+				"Kick Burst: " + yesNo(s.RaidKick),
+			}
+		},
+	},
+	"antidelete": {
+		Title: "ANTI-DELETE",
+		Steps: []WizardStep{
+			{
+				Question: "Where should deleted messages be re-posted?",
+				Options: []WizardOption{
+					{"THIS GROUP", "group"},
+					{"OWNER DM", "dm"},
+				},
+				MinRole: map[string]Role{"dm": RoleSudo},
+			},
+		},
+		IsOn: func(s *GroupSettings) bool { return s.AntiDelete },
+		Apply: func(s *GroupSettings, a []string) {
+			s.AntiDelete = true
+			s.AntiDeleteMode = ""
+			if a[0] == "dm" {
+				s.AntiDeleteMode = "dm"
+			}
+		},
+		Disable: func(s *GroupSettings) { s.AntiDelete = false },
+		Summary: func(s *GroupSettings) []string {
+			if s.AntiDeleteMode == "dm" {
+				return []string{"Re-post to: Owner DM"}
+			}
+			return []string{"Re-post to: Group"}
+		},
+	},
+	"antimedia": {
+		Title: "ANTI-MEDIA",
+		Steps: []WizardStep{
+			{Question: "Which message type should be filtered?", Options: mediaRuleOptions()},
+			actionStep,
+		},
+		IsOn: func(s *GroupSettings) bool { return len(s.MediaRules) > 0 },
+		Apply: func(s *GroupSettings, a []string) {
+			if s.MediaRules == nil {
+				s.MediaRules = make(map[string]string)
+			}
+			s.MediaRules[a[0]] = a[1]
+		},
+		Disable: func(s *GroupSettings) { s.MediaRules = nil },
+		Summary: func(s *GroupSettings) []string {
+			var lines []string
+			for _, t := range mediaRuleTypes {
+				if action := s.MediaRules[t]; action != "" {
+					lines = append(lines, mediaRuleLabels[t]+": "+mediaActionLabel(action))
+				}
+			}
+			return lines
+		},
+	},
+	"antinsfw": {
+		Title: "NSFW FILTER",
+		Steps: []WizardStep{
+			numberStep("Block images scoring at least what %?", 1, 100),
+			actionStep,
+		},
+		IsOn: func(s *GroupSettings) bool { return s.NSFW },
+		Apply: func(s *GroupSettings, a []string) {
+			s.NSFW = true
+			s.NSFWThreshold, _ = strconv.Atoi(a[0])
+			s.NSFWAction = a[1]
+		},
+		Disable: func(s *GroupSettings) { s.NSFW = false },
+		Summary: func(s *GroupSettings) []string {
+			return []string{fmt.Sprintf("Threshold: %d%%", s.NSFWThreshold), "Action: " + actionLabel(s.NSFWAction)}
+		},
+	},
+	"antibug": {
+		Title: "ANTI-BUG (GROUP)",
+		Steps: []WizardStep{
+			{
+				Question: "What should happen to crash messages?",
+				Options: []WizardOption{
+					{"REVOKE (delete for everyone)", "revoke"},
+					{"QUARANTINE (delete + warn)", "quarantine"},
+					{"KICK SENDER", "kick"},
+					{"BLOCK SENDER", "block"},
+				},
+			},
+		},
+		IsOn: func(s *GroupSettings) bool { return s.AntiBug },
+		Apply: func(s *GroupSettings, a []string) {
+			s.AntiBug = true
+			s.AntiBugAction = a[0]
+		},
+		Disable: func(s *GroupSettings) { s.AntiBug = false },
+		Summary: func(s *GroupSettings) []string {
+			return []string{"Action: " + strings.ToUpper(s.AntiBugAction)}
+		},
+	},
+	"antibug_dm": {
+		Title: "ANTI-BUG (DM)",
+		Steps: []WizardStep{
+			{
+				Question: "What should happen to crash messages?",
+				Options: []WizardOption{
+					{"REVOKE (delete for me)", "revoke"},
+					{"QUARANTINE (delete + alert)", "quarantine"},
+					{"BLOCK SENDER", "block"},
+				},
+			},
+			numberStep("Score needed to trigger?", 10, 500),
+		},
+		ApplyBot: func(botID string, a []string) []string {
+			cfg := getAntiBugConfig(botID)
+			cfg.Enabled = true
+			cfg.Action = a[0]
+			cfg.Threshold, _ = strconv.Atoi(a[1])
+			saveAntiBugConfig(botID, cfg)
+			return []string{"Action: " + strings.ToUpper(cfg.Action), fmt.Sprintf("Threshold: %d", cfg.Threshold)}
+		},
+	},
+	"anticall": {
+		Title: "ANTI-CALL",
+		Steps: []WizardStep{
+			{Question: "Reject incoming calls automatically?", Options: yesNoStep},
+			numberStep("Block a caller after how many calls? 0 = never", 0, 20),
+			{
+				Question: "Message to send to callers? (\"off\" for none)",
+				Validate: func(in string) (string, error) {
+					if strings.EqualFold(in, "off") {
+						return "", nil
+					}
+					return in, nil
+				},
+			},
+		},
+		ApplyBot: func(botID string, a []string) []string {
+			cfg := getAntiCallConfig(botID)
+			cfg.Enabled = true
+			cfg.Reject = a[0] == "yes"
+			cfg.BlockAfter, _ = strconv.Atoi(a[1])
+			cfg.Reply = a[2]
+			saveAntiCallConfig(botID, cfg)
+
+			block := "Never"
+			if cfg.BlockAfter > 0 {
+				block = fmt.Sprintf("After %d calls", cfg.BlockAfter)
+			}
+			reply := cfg.Reply
+			if reply == "" {
+				reply = "OFF"
+			}
+			return []string{"Auto Reject: " + yesNo(cfg.Reject), "Block: " + block, "Reply: " + reply}
+		},
+	},
+}
+
+// .antimedia کی قسموں کے نمبر والے آپشنز
+func mediaRuleOptions() []WizardOption {
+	opts := make([]WizardOption, 0, len(mediaRuleTypes))
+	for _, t := range mediaRuleTypes {
+		opts = append(opts, WizardOption{strings.ToUpper(mediaRuleLabels[t]), t})
+	}
+	return opts
+}
+
+// ==================== سیشن اسٹوریج ====================
+
+func wizardUserKey(botID, chatID, user string) string {
+	return "wizard_user:" + botID + ":" + chatID + ":" + getCleanID(user)
+}
+
+func saveWizardState(state *SetupState) {
+	state.ExpiresAt = time.Now().Add(wizardTTL)
+
+	setupMutex.Lock()
+	setupMap[state.BotMsgID] = state
+	setupMutex.Unlock()
+
+	// ⏱️ جواب نہ آئے تو میموری سے بھی صاف کریں (ایکسپائری چیک خود ڈیلیٹ کر دیتا ہے)
+	msgID := state.BotMsgID
+	time.AfterFunc(wizardTTL+time.Second, func() { getWizardState(msgID) })
+
+	if rdb == nil {
+		return
+	}
+	jsonData, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	rdb.Set(ctx, "wizard:"+state.BotMsgID, jsonData, wizardTTL)
+	rdb.Set(ctx, wizardUserKey(state.BotLID, state.GroupID, state.User), state.BotMsgID, wizardTTL)
+}
+
+// پہلے میموری، پھر Redis (ری اسٹارٹ کے بعد بھی سیشن چلتا رہے)
+func getWizardState(msgID string) *SetupState {
+	if msgID == "" {
+		return nil
+	}
+	setupMutex.RLock()
+	state, ok := setupMap[msgID]
+	setupMutex.RUnlock()
+
+	if !ok && rdb != nil {
+		val, err := rdb.Get(ctx, "wizard:"+msgID).Result()
+		if err == nil {
+			var st SetupState
+			if json.Unmarshal([]byte(val), &st) == nil {
+				state = &st
+				setupMutex.Lock()
+				setupMap[msgID] = state
+				setupMutex.Unlock()
+			}
+		}
+	}
+
+	if state == nil {
+		return nil
+	}
+	if time.Now().After(state.ExpiresAt) {
+		deleteWizardState(state)
+		return nil
+	}
+	return state
+}
+
+func deleteWizardState(state *SetupState) {
+	setupMutex.Lock()
+	delete(setupMap, state.BotMsgID)
+	setupMutex.Unlock()
+
+	if rdb == nil {
+		return
+	}
+	rdb.Del(ctx, "wizard:"+state.BotMsgID)
+	userKey := wizardUserKey(state.BotLID, state.GroupID, state.User)
+	if cur, _ := rdb.Get(ctx, userKey).Result(); cur == state.BotMsgID {
+		rdb.Del(ctx, userKey)
+	}
+}
+
+// یوزر کا فعال سیشن (ریپلائی کے بغیر "cancel" کے لیے)
+func activeWizardFor(botID, chatID, user string) *SetupState {
+	if rdb != nil {
+		if msgID, err := rdb.Get(ctx, wizardUserKey(botID, chatID, user)).Result(); err == nil {
+			return getWizardState(msgID)
+		}
+		return nil
+	}
+	setupMutex.RLock()
+	defer setupMutex.RUnlock()
+	for _, st := range setupMap {
+		if st.BotLID == botID && st.GroupID == chatID && st.User == getCleanID(user) && time.Now().Before(st.ExpiresAt) {
+			return st
+		}
+	}
+	return nil
+}
+
+// ==================== فلو ====================
+
+func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []string, secType string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	feature, ok := wizardFeatures[secType]
+	if !ok {
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	groupID := v.Info.Chat.String()
+	settings := getGroupSettings(botID, groupID)
+
+	cmd := ""
+	if len(args) > 0 {
+		cmd = strings.ToLower(args[0])
+	}
+
+	switch cmd {
+	case "":
+		status := "🔴 DISABLED"
+		if feature.IsOn(settings) {
+			status = "🟢 ENABLED"
+		}
+		out := "╔════════════════╗\n"
+		out += "║ 🛡️ " + feature.Title + " STATUS\n"
+		out += "╠════════════════╣\n"
+		out += "║ Status: " + status + "\n"
+		for _, line := range feature.Summary(settings) {
+			out += "║ " + line + "\n"
+		}
+		out += "╠════════════════╣\n"
+		out += "║ Use: ." + secType + " on/off\n"
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	case "off":
+		feature.Disable(settings)
+		saveGroupSettings(botID, settings)
+		replyMessage(client, v, fmt.Sprintf("✅ %s has been DISABLED.", secType))
+
+	case "on":
+		startWizard(client, v, secType, botID, groupID)
+
+	default:
+		replyMessage(client, v, "⚠️ Invalid Usage. Use: on, off or empty.")
+	}
+}
+
+func startWizard(client *whatsmeow.Client, v *events.Message, secType, botID, groupID string) {
+	// پرانا ادھورا سیشن ختم کریں
+	if old := activeWizardFor(botID, groupID, v.Info.Sender.User); old != nil {
+		deleteWizardState(old)
+	}
+
+	state := &SetupState{
+		Type:    secType,
+		Stage:   1,
+		GroupID: groupID,
+		User:    getCleanID(v.Info.Sender.User),
+		BotLID:  botID,
+	}
+	sendWizardStep(client, v.Info.Chat, state)
+}
+
+func sendWizardStep(client *whatsmeow.Client, chat types.JID, state *SetupState) {
+	feature := wizardFeatures[state.Type]
+	step := feature.Steps[state.Stage-1]
+
+	out := "╔════════════════╗\n"
+	out += fmt.Sprintf("║ 🛡️ %s SETUP (%d/%d)\n", feature.Title, state.Stage, len(feature.Steps))
+	out += "╠════════════════╣\n"
+	out += "║ " + step.Question + "\n"
+	for i, opt := range step.Options {
+		out += "║ " + wizardNumbers[i] + " " + opt.Label + "\n"
+	}
+	out += "╠════════════════╣\n"
+	out += "║ ✖️ Reply \"cancel\" to abort\n"
+	out += "╚════════════════╝"
+
+	resp, err := client.SendMessage(context.Background(), chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(out)},
+	})
+	if err != nil {
+		fmt.Printf("❌ [WIZARD] Could not send step %d: %v\n", state.Stage, err)
+		return
+	}
+
+	state.BotMsgID = resp.ID
+	saveWizardState(state)
+}
+
+// بغیر ریپلائی کے "cancel" لکھا ہو تو فعال سیشن ختم کریں (گروپ یا DM، سیشن چیٹ پر ہے)
+func cancelActiveWizard(client *whatsmeow.Client, v *events.Message) bool {
+	if !strings.EqualFold(strings.TrimSpace(getText(v.Message)), "cancel") {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	state := activeWizardFor(botID, v.Info.Chat.String(), v.Info.Sender.User)
+	if state == nil {
+		return false
+	}
+	deleteWizardState(state)
+	replyMessage(client, v, "✖️ Setup cancelled. No settings were changed.")
+	return true
+}
+
+func handleSetupResponse(client *whatsmeow.Client, v *events.Message, state *SetupState) {
+	botID := getCleanID(client.Store.ID.User)
+	if state.BotLID != botID {
+		return // یہ سیشن اس بوٹ کا نہیں ہے
+	}
+	if state.User != getCleanID(v.Info.Sender.User) {
+		return
+	}
+
+	feature, ok := wizardFeatures[state.Type]
+	if !ok {
+		deleteWizardState(state)
+		return
+	}
+
+	txt := strings.TrimSpace(getText(v.Message))
+	if strings.EqualFold(txt, "cancel") {
+		deleteWizardState(state)
+		replyMessage(client, v, "✖️ Setup cancelled. No settings were changed.")
+		return
+	}
+
+	step := feature.Steps[state.Stage-1]
+	var answer string
+	if len(step.Options) > 0 {
+		n, err := strconv.Atoi(txt)
+		if err != nil || n < 1 || n > len(step.Options) {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Please reply with a number from 1 to %d", len(step.Options)))
+			return
+		}
+		answer = step.Options[n-1].Value
+		if need, ok := step.MinRole[answer]; ok && !requireRole(client, v, need) {
+			return
+		}
+	} else {
+		val, err := step.Validate(txt)
+		if err != nil {
+			replyMessage(client, v, "⚠️ "+err.Error())
+			return
+		}
+		answer = val
+	}
+
+	deleteWizardState(state)
+	state.Answers = append(state.Answers, answer)
+
+	// ⏭️ اگلا اسٹیپ
+	if state.Stage < len(feature.Steps) {
+		state.Stage++
+		sendWizardStep(client, v.Info.Chat, state)
+		return
+	}
+
+	// 🏁 فائنل: سب جوابات ایک ساتھ اپلائی کریں
+	var summary []string
+	if feature.ApplyBot != nil {
+		summary = feature.ApplyBot(botID, state.Answers)
+	} else {
+		s := getGroupSettings(botID, state.GroupID)
+		feature.Apply(s, state.Answers)
+		saveGroupSettings(botID, s)
+		summary = feature.Summary(s)
+	}
+
+	out := "╔════════════════╗\n"
+	out += "║ ✅ " + feature.Title + " ENABLED\n"
+	out += "╠════════════════╣\n"
+	for _, line := range summary {
+		out += "║ " + line + "\n"
+	}
+	out += "╚════════════════╝"
+	replyMessage(client, v, out)
+	fmt.Printf("🏁 [COMPLETE] Setup Success for %s on Bot %s\n", state.Type, botID)
+}
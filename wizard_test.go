@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCancelActiveWizard(t *testing.T) {
+	startFakeRedis(t)
+	client := offlineTestClient(t)
+	botID := getCleanID(client.Store.ID.User)
+
+	user := types.NewJID("923330000001", types.DefaultUserServer)
+	other := types.NewJID("923330000002", types.DefaultUserServer)
+	group := types.NewJID("120363000000000777", types.GroupServer)
+
+	tests := []struct {
+		name      string
+		feature   string
+		chat      types.JID
+		sender    types.JID
+		text      string
+		cancelled bool
+	}{
+		{"group cancel", "antilink", group, user, "cancel", true},
+		{"dm cancel anticall", "anticall", user, user, "cancel", true},
+		{"dm cancel antibug", "antibug_dm", user, user, " Cancel ", true},
+		{"dm other text", "anticall", user, user, "2", false},
+		{"other user in group", "antiraid", group, other, "cancel", false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &SetupState{
+				Type:     tt.feature,
+				Stage:    1,
+				GroupID:  tt.chat.String(),
+				User:     getCleanID(user.User),
+				BotLID:   botID,
+				BotMsgID: "WIZ" + string(rune('A'+i)),
+			}
+			saveWizardState(state)
+			t.Cleanup(func() { deleteWizardState(state) })
+
+			v := &events.Message{
+				Info: types.MessageInfo{
+					MessageSource: types.MessageSource{Chat: tt.chat, Sender: tt.sender, IsGroup: tt.chat.Server == types.GroupServer},
+					ID:            "MSG" + state.BotMsgID,
+					Timestamp:     time.Now(),
+				},
+				Message: &waProto.Message{Conversation: proto.String(tt.text)},
+			}
+
+			if got := cancelActiveWizard(client, v); got != tt.cancelled {
+				t.Fatalf("cancelActiveWizard = %v, want %v", got, tt.cancelled)
+			}
+			if alive := getWizardState(state.BotMsgID) != nil; alive == tt.cancelled {
+				t.Errorf("session alive = %v after cancel = %v", alive, tt.cancelled)
+			}
+		})
+	}
+}
+
+// ہر رجسٹرڈ فیچر یا تو گروپ پر یا بوٹ کنفیگ پر اپلائی ہو سکے
+func TestWizardFeaturesComplete(t *testing.T) {
+	for name, f := range wizardFeatures {
+		if len(f.Steps) == 0 || len(f.Steps) > len(wizardNumbers) {
+			t.Errorf("%s: bad step count %d", name, len(f.Steps))
+		}
+		for i, st := range f.Steps {
+			if len(st.Options) == 0 && st.Validate == nil {
+				t.Errorf("%s step %d: no options and no validator", name, i+1)
+			}
+			if len(st.Options) > len(wizardNumbers) {
+				t.Errorf("%s step %d: %d options", name, i+1, len(st.Options))
+			}
+		}
+		if f.ApplyBot == nil && (f.Apply == nil || f.Summary == nil) {
+			t.Errorf("%s: no way to apply answers", name)
+		}
+	}
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ════════════════════════════════════════════════════════════════
+// ⭐ XP & LEVELS
+// ════════════════════════════════════════════════════════════════
+// XP:       Redis Sorted Set "xp:<chatID>" (member = JID، score = XP)
+// Cooldown: Redis "xp_cd:<chatID>:<user>" (ایک منٹ میں ایک بار XP)
+// Titles:   Redis Hash "xp_titles:<chatID>" (cleanID -> ٹائٹل، مائل اسٹون انعام)
+// لیولز: GroupSettings.XPLevels (کل XP کی حدیں)، خالی ہو تو ڈیفالٹ فارمولا۔
+// انعامات: GroupSettings.XPRewards (لیول -> "promote" یا ٹائٹل)
+
+const (
+	xpCooldown    = time.Minute
+	xpMinPerMsg   = 15
+	xpMaxPerMsg   = 25
+	xpMinTextLen  = 3
+	xpBoardMax    = 15
+	xpDefaultStep = 100
+)
+
+func xpKey(chatID string) string {
+	return "xp:" + chatID
+}
+
+// لیول n کے لیے کل XP (ڈیفالٹ: 100, 300, 600, 1000 ...)
+func xpThreshold(s *GroupSettings, level int) int {
+	if level <= 0 {
+		return 0
+	}
+	if len(s.XPLevels) > 0 {
+		if level <= len(s.XPLevels) {
+			return s.XPLevels[level-1]
+		}
+		return -1 // آخری لیول کے بعد کچھ نہیں
+	}
+	return xpDefaultStep * level * (level + 1) / 2
+}
+
+func xpLevelFor(s *GroupSettings, xp int) int {
+	level := 0
+	for {
+		next := xpThreshold(s, level+1)
+		if next < 0 || xp < next {
+			return level
+		}
+		level++
+	}
+}
+
+func xpProgressBar(current, total int) string {
+	const width = 10
+	filled := 0
+	if total > 0 {
+		filled = current * width / total
+	}
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", width-filled)
+}
+
+func xpTitle(chatID string, user types.JID) string {
+	if rdb == nil {
+		return ""
+	}
+	title, _ := rdb.HGet(ctx, "xp_titles:"+chatID, getCleanID(user.User)).Result()
+	return title
+}
+
+// processMessage سے ہر گروپ میسج پر
+func awardMessageXP(client *whatsmeow.Client, v *events.Message) {
+	if rdb == nil {
+		return
+	}
+	s := getGroupSettings(getCleanID(client.Store.ID.User), v.Info.Chat.String())
+	if !s.XPEnabled {
+		return
+	}
+	kind := messageStatsKind(v.Message)
+	if kind == "" || (kind == "t" && len([]rune(strings.TrimSpace(getText(v.Message)))) < xpMinTextLen) {
+		return
+	}
+
+	chatID := v.Info.Chat.String()
+	user := v.Info.Sender.ToNonAD()
+	// ⏳ اسپام سے بچاؤ: کول ڈاؤن میں دوبارہ XP نہیں
+	if ok, _ := rdb.SetNX(ctx, "xp_cd:"+chatID+":"+getCleanID(user.User), 1, xpCooldown).Result(); !ok {
+		return
+	}
+
+	gain := xpMinPerMsg + rand.Intn(xpMaxPerMsg-xpMinPerMsg+1)
+	total, err := rdb.ZIncrBy(ctx, xpKey(chatID), float64(gain), user.String()).Result()
+	if err != nil {
+		return
+	}
+	newLevel := xpLevelFor(s, int(total))
+	oldLevel := xpLevelFor(s, int(total)-gain)
+	if newLevel > oldLevel {
+		go handleLevelUp(client, s, v.Info.Chat, user, oldLevel, newLevel)
+	}
+}
+
+func handleLevelUp(client *whatsmeow.Client, s *GroupSettings, chat, user types.JID, oldLevel, newLevel int) {
+	var rewards []string
+	for lvl := oldLevel + 1; lvl <= newLevel; lvl++ {
+		reward := s.XPRewards[strconv.Itoa(lvl)]
+		switch {
+		case reward == "":
+		case reward == "promote":
+			_, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangePromote)
+			if err != nil {
+				fmt.Printf("⚠️ [XP] Auto-promote %s failed: %v\n", user.User, err)
+				continue
+			}
+			logModAction(client, chat, "promote", fmt.Sprintf("Reached level %d", lvl), types.EmptyJID, user, "")
+			rewards = append(rewards, "👑 Promoted to admin")
+		default:
+			rdb.HSet(ctx, "xp_titles:"+chat.String(), getCleanID(user.User), reward)
+			rewards = append(rewards, "🏷️ New title: "+reward)
+		}
+	}
+
+	if s.XPQuiet && len(rewards) == 0 {
+		return
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🎉 LEVEL UP!
+╠════════════════╣
+║ 👤 @%s
+║ ⭐ Level %d → %d`, user.User, oldLevel, newLevel)
+	for _, r := range rewards {
+		msg += "\n║ " + r
+	}
+	msg += "\n╚════════════════╝"
+	sendMentionText(client, chat, msg, []string{user.String()})
+}
+
+// LID/PN دونوں میں سے جو بھی ZSET میں ریکارڈ ہو وہی ممبر؛ نہ ملے تو user خود
+func xpMember(client *whatsmeow.Client, chatID string, user types.JID) (string, float64) {
+	member := user.String()
+	if xp, err := rdb.ZScore(ctx, xpKey(chatID), member).Result(); err == nil {
+		return member, xp
+	}
+	for _, alias := range getUserAliases(client, user) {
+		for _, server := range []string{types.DefaultUserServer, types.HiddenUserServer} {
+			candidate := types.NewJID(alias, server).String()
+			if xp, err := rdb.ZScore(ctx, xpKey(chatID), candidate).Result(); err == nil {
+				return candidate, xp
+			}
+		}
+	}
+	return member, 0
+}
+
+// ==================== کمانڈز ====================
+
+// .rank [@user]
+func handleRank(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(getCleanID(client.Store.ID.User), chatID)
+	if !s.XPEnabled {
+		replyMessage(client, v, "📴 XP is disabled in this group. Admins can use .xp on")
+		return
+	}
+
+	target := v.Info.Sender.ToNonAD()
+	if jid, _ := resolveTarget(v, args); !jid.IsEmpty() {
+		target = jid.ToNonAD()
+	}
+
+	member, xp := xpMember(client, chatID, target)
+	position := "-"
+	if r, err := rdb.ZRevRank(ctx, xpKey(chatID), member).Result(); err == nil {
+		position = fmt.Sprintf("#%d", r+1)
+	}
+	members, _ := rdb.ZCard(ctx, xpKey(chatID)).Result()
+
+	level := xpLevelFor(s, int(xp))
+	floor := xpThreshold(s, level)
+	next := xpThreshold(s, level+1)
+	progress := "║ 🏁 Max level reached"
+	if next > 0 {
+		progress = fmt.Sprintf("║ %s\n║ 📈 %d / %d XP to level %d", xpProgressBar(int(xp)-floor, next-floor), int(xp)-floor, next-floor, level+1)
+	}
+	title := ""
+	if t := xpTitle(chatID, target); t != "" {
+		title = "\n║ 🏷️ " + t
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ⭐ RANK CARD
+╠════════════════╣
+║ 👤 @%s%s
+║ 🎖️ Level: %d
+║ ✨ XP: %d
+║ 🏆 Rank: %s of %d
+╠════════════════╣
+%s
+╚════════════════╝`, target.User, title, level, int(xp), position, members, progress)
+	sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+}
+
+// .leaderboard [n]
+func handleLeaderboard(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(getCleanID(client.Store.ID.User), chatID)
+	if !s.XPEnabled {
+		replyMessage(client, v, "📴 XP is disabled in this group. Admins can use .xp on")
+		return
+	}
+
+	limit := 10
+	if len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	if limit > xpBoardMax {
+		limit = xpBoardMax
+	}
+
+	entries, _ := rdb.ZRevRangeWithScores(ctx, xpKey(chatID), 0, int64(limit-1)).Result()
+	titles, _ := rdb.HGetAll(ctx, "xp_titles:"+chatID).Result()
+	medals := []string{"🥇", "🥈", "🥉"}
+
+	out := "╔════════════════╗\n"
+	out += "║ 🏆 XP LEADERBOARD\n"
+	out += "╠════════════════╣\n"
+	if len(entries) == 0 {
+		out += "║ 📭 No XP earned yet\n"
+	}
+	var mentions []string
+	for i, e := range entries {
+		jid, err := types.ParseJID(fmt.Sprint(e.Member))
+		if err != nil {
+			continue
+		}
+		rank := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			rank = medals[i]
+		}
+		out += fmt.Sprintf("║ %s @%s\n", rank, jid.User)
+		line := fmt.Sprintf("║    ⭐ Lv %d • %d XP", xpLevelFor(s, int(e.Score)), int(e.Score))
+		if t := titles[getCleanID(jid.User)]; t != "" {
+			line += " • " + t
+		}
+		out += line + "\n"
+		mentions = append(mentions, jid.String())
+	}
+	out += "╚════════════════╝"
+	sendMentionText(client, v.Info.Chat, out, mentions)
+}
+
+// .xp on|off | announce on|off | levels 100,300,... | levels reset |
+// reward <level> promote|<title>|none | rewards | set @user <xp> | reset
+func handleXP(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Database not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+
+	sub := "status"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	switch sub {
+	case "on", "off":
+		s.XPEnabled = sub == "on"
+		saveGroupSettings(botID, s)
+		if s.XPEnabled {
+			replyMessage(client, v, "✅ *XP System:* ON")
+		} else {
+			replyMessage(client, v, "❌ *XP System:* OFF")
+		}
+
+	case "announce":
+		if len(args) == 0 || (args[0] != "on" && args[0] != "off") {
+			replyMessage(client, v, "⚠️ Usage: .xp announce on|off")
+			return
+		}
+		s.XPQuiet = args[0] == "off"
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Level-up announcements: "+strings.ToUpper(args[0]))
+
+	case "levels":
+		if len(args) == 0 {
+			replyMessage(client, v, "⚠️ Usage: .xp levels 100,300,600,1000 | .xp levels reset")
+			return
+		}
+		if strings.ToLower(args[0]) == "reset" {
+			s.XPLevels = nil
+			saveGroupSettings(botID, s)
+			replyMessage(client, v, "✅ Level thresholds reset to default.")
+			return
+		}
+		var levels []int
+		prev := 0
+		for _, part := range strings.Split(strings.Join(args, ","), ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil || n <= prev {
+				replyMessage(client, v, "❌ Thresholds must be increasing numbers, e.g. 100,300,600")
+				return
+			}
+			levels = append(levels, n)
+			prev = n
+		}
+		s.XPLevels = levels
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %d levels set. Max level: %d", len(levels), len(levels)))
+
+	case "reward":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .xp reward <level> promote|<title>|none")
+			return
+		}
+		level, err := strconv.Atoi(args[0])
+		if err != nil || level < 1 {
+			replyMessage(client, v, "❌ Invalid level.")
+			return
+		}
+		reward := strings.TrimSpace(strings.Join(args[1:], " "))
+		if s.XPRewards == nil {
+			s.XPRewards = make(map[string]string)
+		}
+		switch strings.ToLower(reward) {
+		case "none", "off":
+			delete(s.XPRewards, args[0])
+			reward = ""
+		case "promote":
+			reward = "promote"
+			s.XPRewards[args[0]] = reward
+		default:
+			s.XPRewards[args[0]] = reward
+		}
+		saveGroupSettings(botID, s)
+		if reward == "" {
+			replyMessage(client, v, fmt.Sprintf("🗑️ Level %d reward removed.", level))
+			return
+		}
+		replyMessage(client, v, fmt.Sprintf("✅ Level %d reward: %s", level, reward))
+
+	case "rewards":
+		var levels []int
+		for k := range s.XPRewards {
+			if n, err := strconv.Atoi(k); err == nil {
+				levels = append(levels, n)
+			}
+		}
+		sort.Ints(levels)
+		out := "╔════════════════╗\n"
+		out += "║ 🎁 LEVEL REWARDS\n"
+		out += "╠════════════════╣\n"
+		if len(levels) == 0 {
+			out += "║ 📭 None set\n"
+		}
+		for _, lvl := range levels {
+			reward := s.XPRewards[strconv.Itoa(lvl)]
+			if reward == "promote" {
+				reward = "👑 Promote to admin"
+			} else {
+				reward = "🏷️ " + reward
+			}
+			out += fmt.Sprintf("║ ⭐ Lv %d: %s\n", lvl, reward)
+		}
+		out += "╚════════════════╝"
+		replyMessage(client, v, out)
+
+	case "set":
+		target, rest := resolveTarget(v, args)
+		if target.IsEmpty() || len(rest) == 0 {
+			replyMessage(client, v, "⚠️ Usage: .xp set @user <xp>")
+			return
+		}
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n < 0 {
+			replyMessage(client, v, "❌ Invalid XP value.")
+			return
+		}
+		member, _ := xpMember(client, chatID, target.ToNonAD())
+		rdb.ZAdd(ctx, xpKey(chatID), redis.Z{Score: float64(n), Member: member})
+		msg := fmt.Sprintf("✅ @%s now has %d XP (level %d).", target.User, n, xpLevelFor(s, n))
+		sendMentionText(client, v.Info.Chat, msg, []string{target.String()})
+
+	case "reset":
+		rdb.Del(ctx, xpKey(chatID), "xp_titles:"+chatID)
+		replyMessage(client, v, "🗑️ All XP and titles cleared for this group.")
+
+	case "status":
+		status := "🔴 OFF"
+		if s.XPEnabled {
+			status = "🟢 ON"
+		}
+		announce := "ON"
+		if s.XPQuiet {
+			announce = "OFF"
+		}
+		levels := "Default"
+		if len(s.XPLevels) > 0 {
+			levels = fmt.Sprintf("Custom (%d)", len(s.XPLevels))
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ⭐ XP SETTINGS
+╠════════════════╣
+║ Status: %s
+║ 📣 Level-up: %s
+║ 📊 Levels: %s
+║ 🎁 Rewards: %d
+║ ⏳ Cooldown: %s
+╠════════════════╣
+║ .xp on/off
+║ .xp announce on/off
+║ .xp levels 100,300,600
+║ .xp reward 10 promote
+║ .xp reward 5 Veteran
+║ .xp rewards | set | reset
+╚════════════════╝`, status, announce, levels, len(s.XPRewards), formatDuration(xpCooldown))
+		replyMessage(client, v, msg)
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .xp on|off | announce on|off | levels <list> | reward <lvl> <promote|title|none> | rewards | set @user <xp> | reset")
+	}
+}